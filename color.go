@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// colorEnabled resolves the -color flag. "auto" enables color only when stdout
+// is a terminal and the NO_COLOR environment variable is unset (https://no-color.org).
+func colorEnabled(mode string) (bool, error) {
+	switch strings.ToLower(mode) {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		fi, err := os.Stdout.Stat()
+		return err == nil && fi.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid -color flag %q. Please specify auto, always or never", mode)
+	}
+}
+
+// rawColorLines is the number of changed lines rawColors remembers: enough for every
+// line of any error short of a huge stack trace, while memory stays bounded on long
+// colored logs.
+const rawColorLines = 10000
+
+// rawColors remembers the input lines -strip-ansi changed, so that errors report them in
+// Raw as they were read, color codes included, while parsers see them without.
+type rawColors struct {
+	original map[string]rawColorLine // By the line as stripped
+	order    []string                // Stripped lines in the order they were added, oldest first
+	added    int                     // Lines added so far
+}
+
+// rawColorLine is an original input line and when it was added to rawColors.
+type rawColorLine struct {
+	line string
+	seq  int
+}
+
+// add records that original was stripped to stripped, forgetting the oldest line
+// once rawColorLines are remembered.
+func (c *rawColors) add(original, stripped string) {
+	if c.original == nil {
+		c.original = map[string]rawColorLine{}
+	}
+	c.added++
+	c.original[stripped] = rawColorLine{original, c.added}
+	c.order = append(c.order, stripped)
+	if len(c.order) > rawColorLines {
+		oldest := c.order[0]
+		if c.original[oldest].seq == c.added-rawColorLines {
+			delete(c.original, oldest) // Unless it was seen again since
+		}
+		c.order = c.order[1:]
+	}
+}
+
+// restore returns raw with each of its lines as it was read.
+func (c *rawColors) restore(raw string) string {
+	if len(c.original) == 0 {
+		return raw
+	}
+	lines := strings.Split(raw, "\n")
+	for i, line := range lines {
+		if orig, ok := c.original[line]; ok {
+			lines[i] = orig.line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
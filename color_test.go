@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRawColors(t *testing.T) {
+	var c rawColors
+	if got := c.restore("main.go:3:5: error: x"); got != "main.go:3:5: error: x" {
+		t.Errorf("restored %q without colored lines", got)
+	}
+
+	c.add("\x1b[1mmain.go:3:5:\x1b[0m \x1b[31merror:\x1b[0m x", "main.go:3:5: error: x")
+	c.add("\x1b[2m  ^\x1b[0m", "  ^")
+	raw := "main.go:3:5: error: x\n  x := 1\n  ^"
+	if got, want := c.restore(raw), "\x1b[1mmain.go:3:5:\x1b[0m \x1b[31merror:\x1b[0m x\n  x := 1\n\x1b[2m  ^\x1b[0m"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// The oldest lines are forgotten, unless they were seen again since
+	c.add("\x1b[2m  ^\x1b[0m", "  ^")
+	for i := range rawColorLines - 1 {
+		c.add("\x1b[31m"+strconv.Itoa(i)+"\x1b[0m", strconv.Itoa(i))
+	}
+	if got := c.restore("main.go:3:5: error: x"); got != "main.go:3:5: error: x" {
+		t.Errorf("oldest line still restored: %q", got)
+	}
+	if got := c.restore("  ^"); got != "\x1b[2m  ^\x1b[0m" {
+		t.Errorf("line seen again was forgotten: %q", got)
+	}
+	if len(c.original) != rawColorLines {
+		t.Errorf("remembers %d lines, want %d", len(c.original), rawColorLines)
+	}
+}
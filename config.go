@@ -0,0 +1,290 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// --- Config File ---
+// A config file sets defaults for the flags used on every run, in a subset of TOML:
+// top-level keys holding a string or an array of strings, and # comments.
+//
+//	lang = "go"
+//	format = "grouped"
+//	color = "always"
+//	min-severity = "warning"
+//	ignore = [
+//	  '^vendor/',       # Single-quoted strings are taken literally, like in pattern files
+//	  "is deprecated",
+//	]
+//
+// The file is -config if given, else errorparser.toml in the current directory, else
+// in the home directory. Flags given on the command line take precedence over it; an
+// -ignore flag replaces the ignore patterns of the config file rather than adding to them.
+
+// configFileName is the name of the config file looked up by LoadConfig.
+const configFileName = "errorparser.toml"
+
+// Config holds the flag defaults read from a config file. Empty fields are unset.
+type Config struct {
+	Path string // File the config was read from, empty if there was none
+
+	Lang        string
+	Format      string
+	Color       string
+	MinSeverity string
+	Ignore      []string
+}
+
+// LoadConfig reads the config file at path. With an empty path it reads errorparser.toml
+// from the current directory or else the home directory, and returns an empty Config if
+// there is neither.
+func LoadConfig(path string) (Config, error) {
+	if path != "" {
+		return readConfig(path)
+	}
+	dirs := []string{"."}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+	for _, dir := range dirs {
+		cfg, err := readConfig(filepath.Join(dir, configFileName))
+		if !errors.Is(err, fs.ErrNotExist) {
+			return cfg, err
+		}
+	}
+	return Config{}, nil
+}
+
+// readConfig reads and parses the config file at path.
+func readConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg := Config{Path: path}
+	p := &tomlParser{s: string(data), line: 1}
+	seen := map[string]bool{}
+	for {
+		p.skipBlank()
+		if p.s == "" {
+			return cfg, nil
+		}
+		line := p.line // Of the key, as an array value may span lines
+		key, value, err := p.keyValue()
+		if err != nil {
+			return Config{}, fmt.Errorf("%s:%d: %v", path, p.line, err)
+		}
+		if seen[key] {
+			return Config{}, fmt.Errorf("%s:%d: duplicate key %q", path, line, key)
+		}
+		seen[key] = true
+		if err := cfg.set(key, value); err != nil {
+			return Config{}, fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+	}
+}
+
+// set stores the value of a config key: a string, or a []string for an array. A string
+// is accepted for ignore, as a single pattern.
+func (c *Config) set(key string, value any) error {
+	field := map[string]*string{
+		"lang":         &c.Lang,
+		"format":       &c.Format,
+		"color":        &c.Color,
+		"min-severity": &c.MinSeverity,
+	}[key]
+	switch v := value.(type) {
+	case string:
+		if key == "ignore" {
+			c.Ignore = []string{v}
+			return nil
+		}
+		if field != nil {
+			*field = v
+			return nil
+		}
+	case []string:
+		if key == "ignore" {
+			c.Ignore = v
+			return nil
+		}
+		if field != nil {
+			return fmt.Errorf("%s must be a string, not an array", key)
+		}
+	}
+	return fmt.Errorf("unknown key %q, expected lang, format, color, min-severity or ignore", key)
+}
+
+// apply sets the flags the config has values for, unless they were given on the
+// command line. It must be called after flag.Parse.
+func (c Config) apply() error {
+	explicit := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	values := map[string][]string{
+		"lang":         {c.Lang},
+		"format":       {c.Format},
+		"color":        {c.Color},
+		"min-severity": {c.MinSeverity},
+		"ignore":       c.Ignore,
+	}
+	for name, values := range values {
+		if explicit[name] {
+			continue
+		}
+		for _, value := range values {
+			if value == "" {
+				continue // Not set in the config file
+			}
+			if err := flag.Set(name, value); err != nil {
+				return fmt.Errorf("%s: invalid %s %q: %v", c.Path, name, value, err)
+			}
+		}
+	}
+	return nil
+}
+
+// tomlParser parses the subset of TOML that config files use.
+type tomlParser struct {
+	s    string // Input not parsed yet
+	line int    // Line number of the start of s
+}
+
+// skipSpace skips spaces and tabs.
+func (p *tomlParser) skipSpace() {
+	p.s = strings.TrimLeft(p.s, " \t")
+}
+
+// skipBlank skips whitespace, line breaks and comments.
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpace()
+		switch {
+		case strings.HasPrefix(p.s, "#"):
+			end := strings.IndexByte(p.s, '\n')
+			if end < 0 {
+				end = len(p.s)
+			}
+			p.s = p.s[end:]
+		case strings.HasPrefix(p.s, "\r\n"):
+			p.s, p.line = p.s[2:], p.line+1
+		case strings.HasPrefix(p.s, "\n"):
+			p.s, p.line = p.s[1:], p.line+1
+		default:
+			return
+		}
+	}
+}
+
+// keyValue parses a "key = value" line. The value is a string, or a []string for an array.
+func (p *tomlParser) keyValue() (string, any, error) {
+	if strings.HasPrefix(p.s, "[") {
+		return "", nil, errors.New("tables are not supported, keys must be at the top level")
+	}
+	key, err := p.key()
+	if err != nil {
+		return "", nil, err
+	}
+	p.skipSpace()
+	if !strings.HasPrefix(p.s, "=") {
+		return "", nil, fmt.Errorf("expected = after key %q", key)
+	}
+	p.s = p.s[1:]
+	p.skipSpace()
+
+	var value any
+	if strings.HasPrefix(p.s, "[") {
+		value, err = p.array()
+	} else {
+		value, err = p.string()
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	p.skipSpace()
+	if p.s != "" && p.s[0] != '\n' && p.s[0] != '\r' && p.s[0] != '#' {
+		return "", nil, fmt.Errorf("unexpected %q after the value of %q", strings.Fields(p.s)[0], key)
+	}
+	return key, value, nil
+}
+
+// key parses a bare or quoted key.
+func (p *tomlParser) key() (string, error) {
+	if strings.HasPrefix(p.s, `"`) || strings.HasPrefix(p.s, "'") {
+		return p.string()
+	}
+	end := strings.IndexFunc(p.s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_')
+	})
+	if end < 0 {
+		end = len(p.s)
+	}
+	if end == 0 {
+		return "", fmt.Errorf("expected a key, found %q", strings.Fields(p.s)[0])
+	}
+	key := p.s[:end]
+	p.s = p.s[end:]
+	return key, nil
+}
+
+// string parses a basic ("...", with escapes) or literal ('...') string on one line.
+func (p *tomlParser) string() (string, error) {
+	if strings.HasPrefix(p.s, "'") {
+		end := strings.IndexAny(p.s[1:], "'\n")
+		if end < 0 || p.s[1+end] != '\'' {
+			return "", errors.New("unterminated string")
+		}
+		s := p.s[1 : 1+end]
+		p.s = p.s[2+end:]
+		return s, nil
+	}
+	if !strings.HasPrefix(p.s, `"`) {
+		return "", errors.New("expected a quoted string or an array of them")
+	}
+	for i := 1; i < len(p.s) && p.s[i] != '\n'; i++ {
+		switch p.s[i] {
+		case '\\':
+			i++ // Skip the escaped character
+		case '"':
+			s, err := strconv.Unquote(p.s[:i+1])
+			if err != nil {
+				return "", fmt.Errorf("invalid string %s", p.s[:i+1])
+			}
+			p.s = p.s[i+1:]
+			return s, nil
+		}
+	}
+	return "", errors.New("unterminated string")
+}
+
+// array parses an array of strings, which may span lines and end with a comma.
+func (p *tomlParser) array() ([]string, error) {
+	p.s = p.s[1:] // [
+	values := []string{}
+	for {
+		p.skipBlank()
+		if strings.HasPrefix(p.s, "]") {
+			p.s = p.s[1:]
+			return values, nil
+		}
+		s, err := p.string()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, s)
+		p.skipBlank()
+		switch {
+		case strings.HasPrefix(p.s, ","):
+			p.s = p.s[1:]
+		case !strings.HasPrefix(p.s, "]"):
+			return nil, errors.New("expected , or ] in array")
+		}
+	}
+}
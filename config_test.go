@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeConfig writes data to a config file in a temporary directory and returns its path.
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), configFileName)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Config
+	}{
+		{
+			name: "strings and comments",
+			data: "# Defaults for CI\n\nlang = \"go\"  # Trailing comment\nformat = 'grouped'\n\tcolor=\"always\"\nmin-severity = \"warning\"\n",
+			want: Config{Lang: "go", Format: "grouped", Color: "always", MinSeverity: "warning"},
+		},
+		{
+			name: "basic string escapes",
+			data: `ignore = "say \"hi\"\\n\t"`,
+			want: Config{Ignore: []string{"say \"hi\"\\n\t"}},
+		},
+		{
+			name: "literal string taken as is",
+			data: `ignore = '^vendor/\d+ "x"'`,
+			want: Config{Ignore: []string{`^vendor/\d+ "x"`}},
+		},
+		{
+			name: "quoted keys",
+			data: "\"lang\" = \"rust\"\n'format' = \"json\"",
+			want: Config{Lang: "rust", Format: "json"},
+		},
+		{
+			name: "array over lines with comments and a trailing comma",
+			data: "ignore = [\n  '^vendor/',  # Vendored code\n\n  \"is deprecated\",\n]\nlang = \"go\"\n",
+			want: Config{Ignore: []string{"^vendor/", "is deprecated"}, Lang: "go"},
+		},
+		{
+			name: "array on one line",
+			data: `ignore = ["a", 'b']`,
+			want: Config{Ignore: []string{"a", "b"}},
+		},
+		{
+			name: "empty array",
+			data: "ignore = [ ]",
+			want: Config{Ignore: []string{}},
+		},
+		{
+			name: "CRLF line breaks",
+			data: "lang = \"go\"\r\nformat = \"csv\"\r\n",
+			want: Config{Lang: "go", Format: "csv"},
+		},
+		{
+			name: "empty file",
+			data: "",
+			want: Config{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.data)
+			got, err := readConfig(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.want.Path = path
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string // Error after the path of the file
+	}{
+		{"table", "lang = \"go\"\n[output]\nformat = \"json\"", `:2: tables are not supported, keys must be at the top level`},
+		{"missing equals", `lang "go"`, `:1: expected = after key "lang"`},
+		{"missing key", `= "go"`, `:1: expected a key, found "="`},
+		{"bare value", `lang = go`, `:1: expected a quoted string or an array of them`},
+		{"unterminated basic string", "lang = \"go\nformat = \"json\"", `:1: unterminated string`},
+		{"unterminated literal string", `lang = 'go`, `:1: unterminated string`},
+		{"invalid escape", `ignore = "\d+"`, `:1: invalid string "\d+"`},
+		{"second value", `lang = "go" "rust"`, `:1: unexpected "\"rust\"" after the value of "lang"`},
+		{"missing comma", "ignore = [\n  \"a\"\n  \"b\"\n]", `:3: expected , or ] in array`},
+		{"unterminated array", "ignore = [\"a\",", `:1: expected a quoted string or an array of them`},
+		{"unknown key", "\nlanguage = \"go\"", `:2: unknown key "language", expected lang, format, color, min-severity or ignore`},
+		{"array for a string key", "lang = [\"go\"]", `:1: lang must be a string, not an array`},
+		{"duplicate key at the line of the key", "lang = \"go\"\nlang = [\n]", `:2: duplicate key "lang"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.data)
+			_, err := readConfig(path)
+			if err == nil || err.Error() != path+tt.want {
+				t.Errorf("got error %v, want %s", err, path+tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("HOME", t.TempDir()) // No config file in the home directory either
+
+	cfg, err := LoadConfig("")
+	if err != nil || !reflect.DeepEqual(cfg, Config{}) {
+		t.Errorf("without a config file: got %+v, %v, want an empty Config", cfg, err)
+	}
+
+	if err := os.WriteFile(configFileName, []byte(`lang = "go"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig("")
+	if want := (Config{Path: filepath.Join(".", configFileName), Lang: "go"}); err != nil || !reflect.DeepEqual(cfg, want) {
+		t.Errorf("from the current directory: got %+v, %v, want %+v", cfg, err, want)
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "missing.toml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("with a missing -config file: got error %v, want one that it doesn't exist", err)
+	}
+}
+
+// configFlags replaces the command line flags with the ones a config file sets, as
+// declared by main, for the duration of the test.
+func configFlags(t *testing.T) (lang, format, color, minSeverity *string, ignore *stringList) {
+	t.Helper()
+	saved := flag.CommandLine
+	t.Cleanup(func() { flag.CommandLine = saved })
+	flag.CommandLine = flag.NewFlagSet("errorparser", flag.ContinueOnError)
+	lang = flag.String("lang", "", "")
+	format = flag.String("format", "text", "")
+	minSeverity = flag.String("min-severity", "note", "")
+	color = new(string)
+	*color = "auto"
+	flag.Func("color", "", func(s string) error {
+		if s != "auto" && s != "always" && s != "never" {
+			return errors.New("not auto, always or never")
+		}
+		*color = s
+		return nil
+	})
+	ignore = &stringList{}
+	flag.Var(ignore, "ignore", "")
+	return lang, format, color, minSeverity, ignore
+}
+
+func TestConfigApply(t *testing.T) {
+	cfg := Config{Path: "errorparser.toml", Lang: "go", Format: "grouped", MinSeverity: "warning", Ignore: []string{"a", "b"}}
+	tests := []struct {
+		name                             string
+		args                             []string
+		lang, format, color, minSeverity string
+		ignore                           []string
+	}{
+		{"config fills defaults", nil, "go", "grouped", "auto", "warning", []string{"a", "b"}},
+		{"explicit flags win", []string{"-format", "json", "-lang=rust"}, "rust", "json", "auto", "warning", []string{"a", "b"}},
+		{"explicit default value wins", []string{"-min-severity", "note"}, "go", "grouped", "auto", "note", []string{"a", "b"}},
+		{"ignore flag replaces the config patterns", []string{"-ignore", "c"}, "go", "grouped", "auto", "warning", []string{"c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, format, color, minSeverity, ignore := configFlags(t)
+			if err := flag.CommandLine.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := cfg.apply(); err != nil {
+				t.Fatal(err)
+			}
+			got := []any{*lang, *format, *color, *minSeverity, []string(*ignore)}
+			want := []any{tt.lang, tt.format, tt.color, tt.minSeverity, tt.ignore}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got lang, format, color, min-severity, ignore %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestConfigApplyInvalid(t *testing.T) {
+	configFlags(t)
+	if err := flag.CommandLine.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	err := Config{Path: "errorparser.toml", Color: "rainbow"}.apply()
+	if want := `errorparser.toml: invalid color "rainbow": not auto, always or never`; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+}
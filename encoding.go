@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// --- Input Encoding ---
+// Every input is decoded to UTF-8 before scanning. Bytes that are invalid in the
+// selected encoding become U+FFFD, so the rest of the line is still parsed.
+
+// inputEncoding returns the decoder for an -encoding flag value.
+// A byte order mark, if present, overrides the UTF-16 byte order and is stripped.
+func inputEncoding(name string) (encoding.Encoding, error) {
+	switch strings.ToLower(name) {
+	case "utf-8", "utf8":
+		return unicode.UTF8BOM, nil
+	case "utf-16le", "utf16le":
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), nil
+	case "utf-16be", "utf16be":
+		return unicode.UTF16(unicode.BigEndian, unicode.UseBOM), nil
+	case "latin1", "latin-1", "iso-8859-1":
+		return charmap.ISO8859_1, nil
+	}
+	return nil, fmt.Errorf("invalid -encoding flag %q. Please specify utf-8, utf-16le, utf-16be or latin1", name)
+}
+
+// decodeInput wraps r so that it yields UTF-8 text.
+func decodeInput(r io.Reader, enc encoding.Encoding) io.Reader {
+	return transform.NewReader(r, enc.NewDecoder())
+}
@@ -1,7 +1,13 @@
-module errorparser
+module github.com/festeh/errorparser
 
 go 1.24.0
 
 require github.com/alecthomas/participle/v2 v2.1.4
 
+require golang.org/x/text v0.27.0
+
+require github.com/fsnotify/fsnotify v1.10.1
+
+require golang.org/x/sys v0.13.0 // indirect
+
 // indirect requirements are usually managed by go mod tidy
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// --- Compressed Input ---
+// Input files compressed with gzip, such as CI artifacts named build.log.gz, are
+// decompressed transparently. They are recognized by their magic bytes, so a renamed
+// file works too, while a file named .gz without them is reported rather than parsed.
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// gunzipReader yields the content of an input file, decompressed if it is gzip.
+// The format is checked on the first Read, which happens on the reading goroutine
+// (see scanLines), so a slow input such as a named pipe doesn't hold up -timeout.
+type gunzipReader struct {
+	name    string
+	r       io.Reader
+	checked bool
+	gzip    bool  // Whether r decompresses, so its errors mean corrupt data
+	err     error // Error found by the check, returned by every Read
+}
+
+// newGunzipReader returns a gunzipReader for the input file name read from r.
+func newGunzipReader(name string, r io.Reader) *gunzipReader {
+	return &gunzipReader{name: name, r: r}
+}
+
+// check picks the reader for the input from its first bytes.
+func (g *gunzipReader) check() {
+	g.checked = true
+	br := bufio.NewReader(g.r)
+	magic, _ := br.Peek(len(gzipMagic))
+	switch {
+	case bytes.Equal(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			g.err = fmt.Errorf("corrupt gzip file: %w", err)
+			return
+		}
+		g.r, g.gzip = zr, true
+	case strings.HasSuffix(strings.ToLower(g.name), ".gz"):
+		g.err = fmt.Errorf("%s is named .gz but is not a gzip file", g.name)
+	default:
+		g.r = br
+	}
+}
+
+func (g *gunzipReader) Read(p []byte) (int, error) {
+	if !g.checked {
+		g.check()
+	}
+	if g.err != nil {
+		return 0, g.err
+	}
+	n, err := g.r.Read(p)
+	if err != nil && err != io.EOF && g.gzip {
+		err = fmt.Errorf("corrupt gzip file: %w", err) // e.g. truncated, or a checksum mismatch
+	}
+	return n, err
+}
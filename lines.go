@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// --- Input Limits ---
+
+// scanLines reads lines from scanner in a goroutine and sends them on the returned
+// channel, which is closed at the end of the input. Reading in the background lets
+// -timeout stop waiting on an input that never ends, e.g. a stdin pipe that stays
+// open. The goroutine stops when ctx is done. scanner.Err may only be called once
+// the channel is closed.
+func scanLines(ctx context.Context, scanner *bufio.Scanner) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return lines
+}
+
+// lineRange is the 1-based inclusive range of physical lines selected by -lines.
+// A zero end means the end of the input.
+type lineRange struct {
+	start, end int
+}
+
+// parseLineRange parses a -lines value: START:END, START: or :END. An empty value
+// selects every line.
+func parseLineRange(s string) (lineRange, error) {
+	r := lineRange{start: 1}
+	if s == "" {
+		return r, nil
+	}
+	startText, endText, ok := strings.Cut(s, ":")
+	if !ok {
+		return r, fmt.Errorf("invalid -lines %q, expected START:END", s)
+	}
+	var err error
+	if startText != "" {
+		if r.start, err = strconv.Atoi(startText); err != nil || r.start < 1 {
+			return r, fmt.Errorf("invalid -lines start %q, expected a line number from 1", startText)
+		}
+	}
+	if endText != "" {
+		if r.end, err = strconv.Atoi(endText); err != nil || r.end < r.start {
+			return r, fmt.Errorf("invalid -lines end %q, expected a line number from %d", endText, r.start)
+		}
+	}
+	return r, nil
+}
+
+// before and after report whether line number n lies before or after the range.
+func (r lineRange) before(n int) bool { return n < r.start }
+func (r lineRange) after(n int) bool  { return r.end > 0 && n > r.end }
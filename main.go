@@ -1,116 +1,663 @@
+// Command errorparser reads compiler and runtime logs and reports the errors in them.
+//
+// Exit status:
+//
+//	0  no error-severity items were found (warnings and notes don't count)
+//	1  at least one error, panic, exception or test failure was found, unless -no-fail is set
+//	2  invalid usage or an input that could not be read or written
+//	3  -strict is set and a non-empty line matched no error pattern (checked after 1)
+//	4  reading stopped early at -max-lines or -timeout (checked first)
 package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
 	"strings"
+
+	"github.com/festeh/errorparser/output"
+	"github.com/festeh/errorparser/parser"
+)
+
+// Build information, set at build time with e.g.
+//
+//	go build -ldflags "-X main.version=v1.2.0 -X main.commit=$(git rev-parse --short HEAD) -X main.date=$(date -u +%Y-%m-%d)"
+//
+// Unset values fall back to the VCS information Go embeds in the binary.
+var version, commit, date string
+
+// versionString describes the running build for -version.
+func versionString() string {
+	v, c, d := version, commit, date
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			v = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch {
+			case s.Key == "vcs.revision" && c == "":
+				c = s.Value
+			case s.Key == "vcs.time" && d == "":
+				d = s.Value
+			}
+		}
+	}
+	if v == "" {
+		v = "dev"
+	}
+	if c == "" {
+		c = "unknown"
+	}
+	if d == "" {
+		d = "unknown"
+	}
+	return fmt.Sprintf("errorparser %s (commit %s, built %s)", v, c, d)
+}
+
+// stringList is a flag that can be given several times, collecting every value.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// Exit codes, see the package documentation.
+const (
+	exitOK        = 0
+	exitErrors    = 1
+	exitUsage     = 2
+	exitUnmatched = 3
+	exitTruncated = 4
 )
 
 func main() {
 	// --- Language Selection via Flag ---
-	langFlag := flag.String("lang", "", "The language of the log output (flutter, python, go)")
+	langFlag := flag.String("lang", "", "The language of the log output (flutter, python, go, rust, typescript, java, cpp, ruby, php, kotlin, csharp, node, swift, scala, elixir, haskell, msvc, maven, terraform, clojure, perl, webpack, bazel, gojson, ocaml, lua, logcat, custom with -pattern-file, auto to detect it, or all to try every language on each line)")
+	formatFlag := flag.String("format", "text", "Output format: text, json (one object per line), json-array (one array at the end), csv (header and one row per error), tsv (one tab-separated row per error, no header; tabs and newlines escaped as \\t and \\n), grouped (by file, at the end), sarif (one SARIF 2.1.0 document at the end) or html (a self-contained page with a sortable table per file, at the end)")
+	fileFlag := flag.String("file", "", "Read log lines from this file instead of stdin; further files can be given as arguments. Gzip-compressed files (e.g. build.log.gz) are decompressed")
+	encodingFlag := flag.String("encoding", "utf-8", "Encoding of the input: utf-8, utf-16le, utf-16be or latin1; invalid bytes become U+FFFD")
+	maxLines := flag.Int("max-lines", 0, "Stop reading after this many input lines in total, report what was parsed and exit 4; 0 means no limit")
+	timeout := flag.Duration("timeout", 0, "Stop reading after this long (e.g. 30s), report what was parsed and exit 4; 0 means no limit")
+	linesFlag := flag.String("lines", "", "Only parse the physical lines START:END of each input (1-based, inclusive; START: or :END leave a side open). Context before START is not seen, so e.g. a Python error line is reported without a File line that precedes the range")
+	maxLineBytes := flag.Int("max-line-bytes", parser.DefaultMaxLineBytes, "Longest input line accepted, in bytes; longer lines stop reading with an error")
+	stripANSI := flag.Bool("strip-ansi", true, "Remove ANSI color escape sequences from input lines before parsing; raw keeps the lines as they were read")
+	summaryFlag := flag.Bool("summary", false, "Print only error/warning totals at the end")
+	colorFlag := flag.String("color", "auto", "Colorize text output by severity: auto (only on a terminal, unless NO_COLOR is set), always or never")
+	minSeverityFlag := flag.String("min-severity", "note", "Only report errors at least this severe: note (everything, including unmatched lines), warning or error")
+	sortFlag := flag.Bool("sort", false, "Write errors sorted by file, line, column, then severity (errors without a file last) instead of in input order, for reproducible output; not with -format text")
+	first := flag.Bool("first", false, "Stop reading after the first warning or error that is reported (at least -min-severity), e.g. to fail fast on the headline failure of a huge log")
+	dedupFlag := flag.Bool("dedup", false, "Report each distinct error once, with the number of occurrences, after the input ends")
+	relativeTo := flag.String("relative-to", "", "Report paths under this directory relative to it, and strip leading ./ from all paths")
+	shortenModules := flag.String("shorten-module-paths", "", "Shorten paths in Go dependencies printed by -trimpath builds, e.g. example.com/foo@v1.2.3/bar.go: version drops the version (example.com/foo/bar.go), module the whole module prefix (bar.go); the module and version are reported as module and moduleVersion in json")
+	noFail := flag.Bool("no-fail", false, "Exit 0 even if errors were found, for report-only runs")
+	patternFile := flag.String("pattern-file", "", "With -lang custom, read the error line pattern from this YAML file")
+	countOnly := flag.Bool("count-only", false, "Also write the totals to stderr as one JSON object at the end, e.g. {\"errors\":3,\"warnings\":1,\"unmatched\":40}")
+	noTrim := flag.Bool("no-trim", false, "Keep the leading and trailing whitespace of messages exactly as in the input")
+	showInputLine := flag.Bool("show-input-line", false, "Report the 1-based input line each error came from (inputLine in json, an extra column in csv and tsv), e.g. to find a panic without a file location again")
+	withDocURLs := flag.Bool("with-doc-urls", false, "Add a link to the documentation of known diagnostic codes (Rust, TypeScript, C#, C/C++ warnings, GHC, staticcheck and golangci-lint), as docUrl in json")
+	maxFrames := flag.Int("max-frames", 16, "Keep at most this many stack frames of each Go panic (the top user frame is always kept) and report the number dropped as omittedFrames in json; 0 means no limit")
+	normalizeWS := flag.Bool("normalize-whitespace", false, "Collapse runs of spaces and tabs inside messages into a single space")
+	strict := flag.Bool("strict", false, "Treat lines that match no error pattern as failures: print them to stderr and exit 3; empty lines are ignored")
+	loose := flag.Bool("loose", false, "Report the first file:line[:col] of lines the language grammar doesn't match, with type Unknown and severity warning")
+	quiet := flag.Bool("quiet", false, "Print only the parsed errors: no banner, context or unmatched lines")
+	jsonUnmatched := flag.Bool("json-unmatched", false, "In json format, emit unmatched lines as {\"unmatched\": ...} instead of skipping them")
+	watch := flag.Bool("watch", false, "Parse the input file again each time it changes (also when it is truncated or recreated), clearing the screen between runs, until interrupted")
+	configPath := flag.String("config", "", "Read default values of -lang, -format, -color, -min-severity and -ignore from this TOML file instead of errorparser.toml in the current directory or else the home directory; flags on the command line take precedence")
+	versionFlag := flag.Bool("version", false, "Print the version, commit and build date, then exit")
+	var ignorePatterns, onlyPatterns stringList
+	flag.Var(&ignorePatterns, "ignore", "Drop errors whose message or filename matches this regular expression; can be repeated")
+	flag.Var(&onlyPatterns, "only", "Report only errors whose message or filename matches this regular expression; can be repeated to allow several")
 	flag.Parse()
 
-	var selectedLang Language
+	if *versionFlag {
+		fmt.Println(versionString())
+		os.Exit(exitOK)
+	}
+
+	cfg, err := LoadConfig(*configPath)
+	if err == nil {
+		err = cfg.apply()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid config file: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	var selectedLang parser.Language
 	switch strings.ToLower(*langFlag) {
 	case "flutter":
-		selectedLang = LangFlutter
+		selectedLang = parser.LangFlutter
 	case "python":
-		selectedLang = LangPython
+		selectedLang = parser.LangPython
 	case "go":
-		selectedLang = LangGo
+		selectedLang = parser.LangGo
 	case "rust":
-		selectedLang = LangRust
+		selectedLang = parser.LangRust
+	case "typescript":
+		selectedLang = parser.LangTypeScript
+	case "java":
+		selectedLang = parser.LangJava
+	case "cpp", "c", "c++":
+		selectedLang = parser.LangCpp
+	case "ruby":
+		selectedLang = parser.LangRuby
+	case "php":
+		selectedLang = parser.LangPHP
+	case "kotlin":
+		selectedLang = parser.LangKotlin
+	case "csharp", "cs", "c#":
+		selectedLang = parser.LangCSharp
+	case "node", "javascript", "js":
+		selectedLang = parser.LangNode
+	case "swift":
+		selectedLang = parser.LangSwift
+	case "scala", "sbt":
+		selectedLang = parser.LangScala
+	case "elixir", "mix":
+		selectedLang = parser.LangElixir
+	case "haskell", "ghc":
+		selectedLang = parser.LangHaskell
+	case "msvc", "cl", "msbuild":
+		selectedLang = parser.LangMSVC
+	case "auto":
+		selectedLang = parser.LangAuto
+	case "all":
+		selectedLang = parser.LangAll
+	case "maven", "mvn":
+		selectedLang = parser.LangMaven
+	case "terraform", "tf":
+		selectedLang = parser.LangTerraform
+	case "clojure", "clj", "lein":
+		selectedLang = parser.LangClojure
+	case "perl":
+		selectedLang = parser.LangPerl
+	case "webpack":
+		selectedLang = parser.LangWebpack
+	case "bazel":
+		selectedLang = parser.LangBazel
+	case "gojson", "go-json":
+		selectedLang = parser.LangGoJSON
+	case "ocaml", "dune":
+		selectedLang = parser.LangOCaml
+	case "lua", "luajit":
+		selectedLang = parser.LangLua
+	case "logcat", "android":
+		selectedLang = parser.LangLogcat
+	case "custom":
+		if *patternFile == "" {
+			fmt.Fprintf(os.Stderr, "Error: -lang custom requires -pattern-file.\n")
+			os.Exit(exitUsage)
+		}
+		custom, err := loadCustomLanguage(*patternFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid pattern file: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		selectedLang = custom
 	default:
-		fmt.Fprintf(os.Stderr, "Error: Invalid or missing -lang flag. Please specify flutter, python, go, or rust.\n")
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "Error: Invalid or missing -lang flag. Please specify flutter, python, go, rust, typescript, java, cpp, ruby, php, kotlin, csharp, node, swift, scala, elixir, haskell, msvc, maven, terraform, clojure, perl, webpack, bazel, gojson, ocaml, lua, logcat, custom, auto, or all.\n")
+		os.Exit(exitUsage)
 	}
 
-	// --- Input Processing ---
-	scanner := bufio.NewScanner(os.Stdin)
-	fmt.Printf("Parsing for language: %s. Enter log lines (Ctrl+D to end):\n", *langFlag)
+	minSeverity, ok := parser.ParseSeverity(*minSeverityFlag)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: Invalid -min-severity flag %q. Please specify note, warning or error.\n", *minSeverityFlag)
+		os.Exit(exitUsage)
+	}
+
+	filter, err := parser.NewFilter(ignorePatterns, onlyPatterns)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	if *maxLineBytes <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: Invalid -max-line-bytes %d, it must be positive.\n", *maxLineBytes)
+		os.Exit(exitUsage)
+	}
 
-	var lastPythonFileRef *PythonFileRef // Holds context between lines specifically for Python errors
+	selectedLines, err := parseLineRange(*linesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	if *maxLines < 0 || *timeout < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-lines and -timeout must not be negative.\n")
+		os.Exit(exitUsage)
+	}
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		currentPythonFileRef := lastPythonFileRef // Preserve ref from previous line for this iteration (Python only)
-		if selectedLang != LangPython {
-			currentPythonFileRef = nil // Not needed for other languages
+	inputEnc, err := inputEncoding(*encodingFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	useColor, err := colorEnabled(*colorFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	switch *shortenModules {
+	case "", "version", "module":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid -shorten-module-paths flag %q. Please specify version or module.\n", *shortenModules)
+		os.Exit(exitUsage)
+	}
+	keepModule := *shortenModules == "version"
+
+	root := *relativeTo
+	if root != "" {
+		if root, err = filepath.Abs(root); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid -relative-to directory: %v\n", err)
+			os.Exit(exitUsage)
 		}
-		lastPythonFileRef = nil // Reset context for the *next* iteration by default
+	}
 
-		if line == "" {
-			continue
+	// --- Output Format Selection ---
+	var emitter output.Emitter
+	var textEmitter *output.Text // Text output names the language of each input, see parseInput
+	switch strings.ToLower(*formatFlag) {
+	case "text":
+		textEmitter = output.NewText(os.Stdout)
+		textEmitter.Color = useColor
+		emitter = textEmitter
+	case "json", "json-array":
+		j := output.NewJSON(os.Stdout)
+		j.Array = strings.EqualFold(*formatFlag, "json-array") // Same objects as json, buffered into one array
+		j.Counts, j.Unmatched = *dedupFlag, *jsonUnmatched
+		emitter = j
+	case "csv":
+		c := output.NewCSV(os.Stdout)
+		c.Counts, c.InputLine = *dedupFlag, *showInputLine
+		emitter = c
+	case "tsv":
+		t := output.NewTSV(os.Stdout)
+		t.Counts, t.InputLine = *dedupFlag, *showInputLine
+		emitter = t
+	case "grouped":
+		g := output.NewGrouped(os.Stdout) // Results are buffered, grouped and written at the end
+		g.Color = useColor
+		emitter = g
+	case "sarif":
+		emitter = output.NewSARIF(os.Stdout) // Results are buffered and written as one document at the end
+	case "html":
+		emitter = output.NewHTML(os.Stdout) // Results are buffered and written as one page at the end
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Invalid -format flag %q. Please specify text, json, json-array, csv, tsv, grouped, sarif or html.\n", *formatFlag)
+		os.Exit(exitUsage)
+	}
+	if *sortFlag {
+		if textEmitter != nil {
+			fmt.Fprintf(os.Stderr, "Error: -sort needs a -format other than text.\n")
+			os.Exit(exitUsage)
 		}
+		emitter = output.NewSorted(emitter) // Buffers everything until Close
+	}
 
-		// --- Parsing ---
-		parsedResult, err := ParseLine(line, selectedLang)
-		if err != nil {
-			// ParseLine now tries to return UnmatchedLine instead of error for non-matching lines.
-			// An error here indicates a more fundamental parsing issue or unknown language.
-			fmt.Fprintf(os.Stderr, "Parser internal error: %v\n", err)
-			continue
-		}
-
-		// --- Handle Parsed Result ---
-		switch v := parsedResult.(type) {
-		case *FlutterError:
-			info := v.ToErrorInfo()
-			fmt.Printf("Parsed Error (Flutter): %+v\n", info)
-		case *GoParseResult:
-			if v.CompileError != nil {
-				info := v.CompileError.ToErrorInfo()
-				fmt.Printf("Parsed Error (Go Compile): %+v\n", info)
-			} else if v.Panic != nil {
-				info := v.Panic.ToErrorInfo()
-				fmt.Printf("Parsed Error (Go Panic): %+v\n", info)
-			} else {
-				// Should not happen if parser logic is correct
-				fmt.Printf("Parsed Go Structure (Empty): %+v\n", v)
-			}
-		case *PythonParseResult:
-			if v.FileRef != nil {
-				// Store Python File context for the *next* line
-				lastPythonFileRef = v.FileRef // Override the default nil reset
-				fmt.Printf("Context (Python File): %s, Line %d\n", v.FileRef.Filename, v.FileRef.Line)
-			} else if v.Error != nil {
-				// Construct ErrorInfo for the Python error line
-				info := ErrorInfo{
-					Type:    v.Error.ErrType,
-					Message: strings.TrimSpace(v.Error.Message),
+	// Inputs are the -file flag followed by the positional arguments, in order; stdin if there are none
+	paths := flag.Args()
+	if *fileFlag != "" {
+		paths = append([]string{*fileFlag}, paths...)
+	}
+
+	if *watch {
+		if len(paths) != 1 {
+			fmt.Fprintf(os.Stderr, "Error: -watch needs exactly one input file.\n")
+			os.Exit(exitUsage)
+		}
+		if err := watchFile(paths[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Cannot watch %s: %v\n", paths[0], err)
+			os.Exit(exitUsage)
+		}
+		os.Exit(exitOK)
+	}
+
+	// Tallies of everything reported, used by -summary
+	typeCounts := map[string]int{}
+	errorCount, warningCount, unmatchedCount := 0, 0, 0
+	maxSeverity := parser.SevNote       // Most severe item reported, decides the exit code
+	var dedupPending []parser.ErrorInfo // Everything reported with -dedup, emitted at the end
+
+	lang := selectedLang // Language of the input being parsed, resolved per input for -lang auto
+
+	// emit prints a parsed ErrorInfo that occurred count times in the selected output format.
+	emit := func(info parser.ErrorInfo, count int) {
+		sev := info.Severity()
+		maxSeverity = max(maxSeverity, sev)
+		typeCounts[info.Type]++
+		switch sev {
+		case parser.SevWarning:
+			warningCount++
+		case parser.SevError:
+			errorCount++ // Errors, panics, exceptions and test failures all count as errors
+		}
+		if *summaryFlag {
+			return // Only the totals are printed
+		}
+		if err := emitter.Emit(info, count); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		}
+	}
+
+	// With -first, reading stops once firstSeen; notes don't count, even with -min-severity note
+	firstSeverity := max(minSeverity, parser.SevWarning)
+	firstSeen := false
+
+	// report filters a parsed ErrorInfo and emits it, or holds it back for -dedup.
+	var colors rawColors // Input lines -strip-ansi changed, restored in Raw
+	report := func(info parser.ErrorInfo) {
+		if firstSeen {
+			return // -first: errors completed by the same line as the first one are dropped
+		}
+		if info.Severity() < minSeverity {
+			return
+		}
+		info.Raw = colors.restore(info.Raw)
+		if *normalizeWS {
+			info.Message = parser.NormalizeMessage(info.Message) // Applied here so every language gets it alike
+		}
+		if !*showInputLine {
+			info.InputLine = 0
+		}
+		if *withDocURLs && info.Code != "" {
+			docLang := lang
+			if info.DetectedLang != "" {
+				docLang, _ = parser.LanguageByName(info.DetectedLang) // -lang all
+			}
+			info.DocURL, _ = parser.DocURL(docLang, info.Code)
+		}
+		if *relativeTo != "" {
+			info.Filename = parser.NormalizePath(info.Filename, root)
+			for i := range info.Frames {
+				info.Frames[i].Filename = parser.NormalizePath(info.Frames[i].Filename, root)
+			}
+		}
+		if *shortenModules != "" {
+			info.Filename, info.Module, info.ModuleVersion = parser.ShortenModulePath(info.Filename, keepModule)
+			for i := range info.Frames {
+				info.Frames[i].Filename, _, _ = parser.ShortenModulePath(info.Frames[i].Filename, keepModule)
+			}
+		}
+		if !filter.Keep(info) {
+			return // Matched after -relative-to and -shorten-module-paths, so patterns see the paths as printed
+		}
+		if *first && info.Severity() >= firstSeverity {
+			firstSeen = true
+		}
+		if *dedupFlag {
+			dedupPending = append(dedupPending, info)
+			return
+		}
+		emit(info, 1)
+	}
+
+	// writeCounts writes the -count-only totals to stderr. It runs once, also when reading fails midway.
+	countsWritten := false
+	writeCounts := func() {
+		if !*countOnly || countsWritten {
+			return
+		}
+		countsWritten = true
+		counts := struct {
+			Errors    int `json:"errors"`
+			Warnings  int `json:"warnings"`
+			Unmatched int `json:"unmatched"`
+		}{errorCount, warningCount, unmatchedCount}
+		if err := json.NewEncoder(os.Stderr).Encode(counts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing counts: %v\n", err)
+		}
+	}
+
+	// --- Input Processing ---
+	// parseInput parses one input with its own Assembler, so multi-line context
+	// (Python tracebacks, Rust locations) never leaks from one file into the next.
+	// name is empty for stdin.
+	lineCount := 0  // Input lines read so far, for -max-lines
+	truncated := "" // The limit that stopped reading early, if any
+	parseInput := func(name string, input io.Reader) error {
+		scanner := bufio.NewScanner(decodeInput(input, inputEnc))
+		scanner.Buffer(nil, *maxLineBytes)
+		inputCtx, cancel := context.WithCancel(ctx)
+		defer cancel() // Stops the reading goroutine when a limit ends this input early
+		lines := scanLines(inputCtx, scanner)
+		lineNo := 0        // Physical line number in this input, including lines skipped by -lines
+		pastRange := false // Whether reading stopped at the end of the -lines range
+
+		// next returns the next input line in the -lines range, or false at the end of
+		// the input or the range, or at a limit
+		next := func() (string, bool) {
+			for {
+				if *maxLines > 0 && lineCount >= *maxLines {
+					truncated = fmt.Sprintf("-max-lines %d", *maxLines)
+					return "", false
+				}
+				if selectedLines.after(lineNo + 1) {
+					pastRange = true // The deferred cancel stops reading the rest
+					return "", false
+				}
+				select {
+				case line, ok := <-lines:
+					if !ok {
+						return "", false
+					}
+					lineCount++
+					lineNo++
+					if selectedLines.before(lineNo) {
+						continue
+					}
+					return line, true
+				case <-ctx.Done():
+					truncated = fmt.Sprintf("-timeout %s", *timeout)
+					return "", false
+				}
+			}
+		}
+
+		// With -lang auto, buffer a sample of the input to detect the language from,
+		// then replay the buffered lines through the normal loop below.
+		lang = selectedLang
+		var buffered []string
+		if lang == parser.LangAuto {
+			var sample []string // buffered as the parsers see it
+			nonEmpty := 0
+			blank := true // Whether the sample holds only whitespace
+			for nonEmpty < parser.DetectSampleSize {
+				line, ok := next()
+				if !ok {
+					break
+				}
+				buffered = append(buffered, line)
+				if *stripANSI {
+					line = parser.StripANSI(line)
+				}
+				sample = append(sample, line)
+				if line != "" {
+					nonEmpty++
 				}
-				// Combine with context from the previous line if available
-				if currentPythonFileRef != nil {
-					info.Filename = currentPythonFileRef.Filename
-					info.Line = currentPythonFileRef.Line
-					fmt.Printf("Parsed Error (Python Context): %+v\n", info)
-				} else {
-					// Print Python error without file context
-					fmt.Printf("Parsed Error (Python): %+v\n", info)
+				if strings.TrimSpace(line) != "" {
+					blank = false
 				}
+			}
+			lang = parser.DetectLanguage(sample)
+			if lang == parser.LangUnknown && blank {
+				return nil // Empty or blank input has no errors, whatever its language
+			}
+			if lang == parser.LangUnknown {
+				fmt.Fprintf(os.Stderr, "Error: Could not detect the language of the input. Please specify -lang explicitly.\n")
+				os.Exit(exitUsage)
+			}
+			if !*quiet {
+				fmt.Fprintf(os.Stderr, "Detected language: %s\n", lang)
+			}
+		}
+
+		// The banner goes to stderr, so stdout only carries parse results
+		if textEmitter != nil {
+			textEmitter.Lang = lang
+		}
+		if textEmitter != nil && !*summaryFlag && !*quiet {
+			if name != "" {
+				fmt.Fprintf(os.Stderr, "Parsing for language: %s. Reading %s:\n", lang, name)
 			} else {
-				// Should not happen if parser logic is correct
-				fmt.Printf("Parsed Python Structure (Empty): %+v\n", v)
+				fmt.Fprintf(os.Stderr, "Parsing for language: %s. Enter log lines (Ctrl+D to end):\n", lang)
 			}
-		case *RustMsgLine:
-			// Rust errors/warnings often print details on subsequent lines,
-			// which will be caught as Unmatched. This handles the main message line.
-			info := v.ToErrorInfo()
-			fmt.Printf("Parsed Message (Rust): %+v\n", info)
-		case *UnmatchedLine:
-			// Print lines that didn't match the specific language's error patterns
-			fmt.Printf("Unmatched Line: %s\n", v.Content)
-		default:
-			// This case should ideally not be reached if ParseLine handles all types
-			fmt.Printf("Parsed but Unrecognized Type: %T %+v\n", v, v)
 		}
+
+		// The assembler holds multi-line context (Python file refs, Rust locations) between lines
+		assembler := parser.NewAssembler(lang)
+		assembler.SetNoTrim(*noTrim)
+		assembler.SetMaxFrames(*maxFrames)
+		assembler.SkipLines(selectedLines.start - 1) // Lines before -lines are read but not fed
+
+		processLine := func(line string) {
+			if *stripANSI {
+				if stripped := parser.StripANSI(line); stripped != line {
+					colors.add(line, stripped) // Raw keeps the line as it was read
+					line = stripped
+				}
+			}
+			infos, matched := assembler.Feed(line)
+			for _, info := range infos {
+				report(info)
+			}
+			if line == "" {
+				return
+			}
+			if !matched && *loose {
+				if info, ok := parser.LooseMatch(line); ok {
+					report(info)
+					infos, matched = append(infos, info), true
+				}
+			}
+			if !matched {
+				unmatchedCount++
+				if *strict {
+					if name != "" {
+						fmt.Fprintf(os.Stderr, "Unmatched line in %s: %s\n", name, line)
+					} else {
+						fmt.Fprintf(os.Stderr, "Unmatched line: %s\n", line)
+					}
+				}
+			}
+
+			switch {
+			case *summaryFlag, *quiet, minSeverity > parser.SevNote:
+				// Per-line output is suppressed, also when filtering by severity
+			case !matched || len(infos) == 0:
+				// Unmatched, or matched but held back as context for an error on a following line
+				if err := emitter.Line(line, matched); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+				}
+			}
+		}
+
+		for _, line := range buffered {
+			if firstSeen {
+				break
+			}
+			processLine(line)
+		}
+		for truncated == "" && !firstSeen {
+			line, ok := next()
+			if !ok {
+				break
+			}
+			processLine(line)
+		}
+		if firstSeen {
+			return nil // Errors are complete when the Assembler returns them, so nothing pending matters
+		}
+		for _, info := range assembler.Flush() {
+			report(info)
+		}
+		if truncated != "" || pastRange {
+			return nil // The reading goroutine may still be scanning, so scanner.Err isn't safe
+		}
+		if err := scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("a line is longer than %d bytes, raise -max-line-bytes: %w", *maxLineBytes, err)
+		}
+		return scanner.Err()
 	}
 
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
-		os.Exit(1)
+	if len(paths) == 0 {
+		if err := parseInput("", os.Stdin); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+			writeCounts()
+			os.Exit(exitUsage)
+		}
+	}
+	for _, path := range paths {
+		if truncated != "" || firstSeen {
+			break
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Cannot open input file: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		err = parseInput(path, newGunzipReader(path, f)) // .gz files are decompressed, see gunzipReader
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", path, err)
+			writeCounts()
+			os.Exit(exitUsage)
+		}
+	}
+	for _, e := range parser.Dedup(dedupPending) {
+		emit(e.ErrorInfo, e.Count)
+	}
+
+	if !*summaryFlag {
+		if err := emitter.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if *summaryFlag {
+		fmt.Printf("Summary: %d errors, %d warnings, %d unmatched lines\n", errorCount, warningCount, unmatchedCount)
+		types := make([]string, 0, len(typeCounts))
+		for t := range typeCounts {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			fmt.Printf("  %s: %d\n", t, typeCounts[t])
+		}
+	}
+
+	writeCounts()
+
+	if truncated != "" {
+		fmt.Fprintf(os.Stderr, "Input truncated after %d lines by %s: %d errors, %d warnings, %d unmatched lines\n", lineCount, truncated, errorCount, warningCount, unmatchedCount)
+		os.Exit(exitTruncated)
+	}
+	if maxSeverity == parser.SevError && !*noFail {
+		os.Exit(exitErrors) // Let CI fail the build
+	}
+	if *strict && unmatchedCount > 0 {
+		os.Exit(exitUnmatched) // The log format is not fully understood
 	}
+	os.Exit(exitOK)
 }
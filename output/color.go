@@ -0,0 +1,33 @@
+package output
+
+import "github.com/festeh/errorparser/parser"
+
+// --- Terminal Colors ---
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
+)
+
+// severityColor returns the color for a severity: yellow for warnings,
+// dim for notes and red for errors (including panics, exceptions and test failures).
+func severityColor(sev parser.Severity) string {
+	switch sev {
+	case parser.SevWarning:
+		return ansiYellow
+	case parser.SevNote:
+		return ansiDim
+	default:
+		return ansiRed
+	}
+}
+
+// colorize wraps s in the given color, leaving empty strings alone.
+func colorize(s, color string) string {
+	if s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
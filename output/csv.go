@@ -0,0 +1,81 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- CSV Output ---
+// One header row followed by one row per error, quoted by encoding/csv.
+// With InputLine an input_line column is appended, and with Counts a count column.
+
+var csvHeader = []string{"filename", "line", "column", "type", "code", "message"}
+
+// CSV writes errors as CSV rows, flushing each row as it is written.
+type CSV struct {
+	Counts    bool // Append a count column, for deduplicated results
+	InputLine bool // Append an input_line column with ErrorInfo.InputLine
+
+	w             *csv.Writer
+	headerWritten bool
+}
+
+// NewCSV returns a CSV emitter writing to w.
+func NewCSV(w io.Writer) *CSV {
+	return &CSV{w: csv.NewWriter(w)}
+}
+
+// csvRecord returns the CSV row for info. An absent column is left empty.
+func csvRecord(info parser.ErrorInfo) []string {
+	column := ""
+	if info.Column != nil {
+		column = strconv.Itoa(*info.Column)
+	}
+	return []string{info.Filename, strconv.Itoa(info.Line), column, info.Type, info.Code, info.Message}
+}
+
+// writeHeader writes the header row once, before the first row or on Close.
+func (c *CSV) writeHeader() {
+	if c.headerWritten {
+		return
+	}
+	c.headerWritten = true
+	header := csvHeader
+	if c.InputLine {
+		header = append(header, "input_line")
+	}
+	if c.Counts {
+		header = append(header, "count")
+	}
+	c.w.Write(header)
+}
+
+// Emit writes the row for info.
+func (c *CSV) Emit(info parser.ErrorInfo, count int) error {
+	c.writeHeader()
+	record := csvRecord(info)
+	if c.InputLine {
+		record = append(record, strconv.Itoa(info.InputLine))
+	}
+	if c.Counts {
+		record = append(record, strconv.Itoa(count))
+	}
+	c.w.Write(record)
+	c.w.Flush() // Stream rows as they are parsed
+	return c.w.Error()
+}
+
+// Line does nothing, CSV only holds errors.
+func (c *CSV) Line(string, bool) error {
+	return nil
+}
+
+// Close writes the header if there were no errors.
+func (c *CSV) Close() error {
+	c.writeHeader()
+	c.w.Flush()
+	return c.w.Error()
+}
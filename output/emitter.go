@@ -0,0 +1,137 @@
+// Package output renders parsed errors in the formats of the errorparser command:
+// human readable text, JSON, CSV, TSV, SARIF, grouped by file and an HTML page. Every format implements
+// Emitter and writes to an io.Writer, so library users and tests can render results
+// the same way the command does. Sorted wraps any of them to write in a stable order.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// Emitter writes parse results in one output format.
+// Formats that need all results at once (SARIF, grouped, a JSON array) buffer them
+// until Close; the others write as they go.
+type Emitter interface {
+	// Emit writes an error that occurred count times; count is 1 unless results are deduplicated.
+	Emit(info parser.ErrorInfo, count int) error
+	// Line writes an input line that produced no error: unmatched, or matched but held
+	// back as context for an error on a following line. Most formats ignore it.
+	Line(line string, matched bool) error
+	// Close writes anything still buffered. The Emitter must not be used afterwards.
+	Close() error
+}
+
+// --- Text Output ---
+
+// Text writes one human readable line per error, in the style of the original command:
+//
+//	Parsed Error (go): {Filename:main.go Line:3 ...}
+type Text struct {
+	Lang  parser.Language // Language shown with each error, unless the error records its own
+	Color bool            // Colorize the type and file by severity
+
+	w io.Writer
+}
+
+// NewText returns a Text emitter writing to w.
+func NewText(w io.Writer) *Text {
+	return &Text{w: w}
+}
+
+// Emit writes info. A code is shown as a compiler-style "[code]" message prefix and
+// counts above one are appended as (xN).
+func (t *Text) Emit(info parser.ErrorInfo, count int) error {
+	if info.Code != "" {
+		info.Message = "[" + info.Code + "] " + info.Message // Keep the familiar compiler-style prefix for humans
+	}
+	typ := info.Type
+	if t.Color {
+		color := severityColor(info.Severity())
+		typ = colorize(typ, color)
+		info.Filename = colorize(info.Filename, color)
+	}
+	suffix := ""
+	if count > 1 {
+		suffix = fmt.Sprintf(" (x%d)", count)
+	}
+	name := t.Lang.String()
+	if info.DetectedLang != "" {
+		name = info.DetectedLang // -lang all
+	}
+	_, err := fmt.Fprintf(t.w, "Parsed %s (%s): %+v%s\n", typ, name, info, suffix)
+	return err
+}
+
+// Line writes unmatched and context lines, so the whole input can be followed.
+func (t *Text) Line(line string, matched bool) error {
+	var err error
+	if matched {
+		_, err = fmt.Fprintf(t.w, "Context Line: %s\n", line)
+	} else {
+		_, err = fmt.Fprintf(t.w, "Unmatched Line: %s\n", line)
+	}
+	return err
+}
+
+// Close does nothing, Text writes as it goes.
+func (t *Text) Close() error {
+	return nil
+}
+
+// --- JSON Output ---
+
+// JSON writes one compact JSON object per error followed by a newline (NDJSON),
+// or with Array a single JSON array of them on Close.
+type JSON struct {
+	Array     bool // Buffer everything and write one array on Close; no results give []
+	Counts    bool // Write parser.CountedError objects with a count field, for deduplicated results
+	Unmatched bool // Also write unmatched lines as {"unmatched": "..."} objects
+
+	enc    *json.Encoder
+	values []any // Buffered objects with Array
+}
+
+// NewJSON returns a JSON emitter writing to w.
+func NewJSON(w io.Writer) *JSON {
+	return &JSON{enc: json.NewEncoder(w)}
+}
+
+// Emit writes info, or buffers it with Array.
+func (j *JSON) Emit(info parser.ErrorInfo, count int) error {
+	if j.Counts {
+		return j.write(parser.CountedError{ErrorInfo: info, Count: count})
+	}
+	return j.write(info)
+}
+
+// Line writes unmatched lines if Unmatched is set. Context lines are never written.
+func (j *JSON) Line(line string, matched bool) error {
+	if matched || !j.Unmatched {
+		return nil
+	}
+	return j.write(map[string]string{"unmatched": line})
+}
+
+// write writes one JSON output object, or buffers it with Array.
+func (j *JSON) write(v any) error {
+	if j.Array {
+		j.values = append(j.values, v)
+		return nil
+	}
+	return j.enc.Encode(v)
+}
+
+// Close writes the array with Array.
+func (j *JSON) Close() error {
+	if !j.Array {
+		return nil
+	}
+	if j.values == nil {
+		j.values = []any{} // Never null, so no results is []
+	}
+	return j.enc.Encode(j.values)
+}
@@ -0,0 +1,150 @@
+package output
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Grouped Output ---
+// Errors grouped under their file, in the spirit of ESLint's stylish format:
+//
+//	src/app.ts
+//	  10:5  Error    Type 'string' is not assignable to type 'number'.  TS2322
+//	  12    Warning  'x' is declared but never used.
+//
+// Files are sorted by name, with errors that have no file last; within a file
+// errors are sorted by line, then column.
+
+// compareGrouped orders errors for grouped output.
+func compareGrouped(a, b parser.CountedError) int {
+	if (a.Filename == "") != (b.Filename == "") {
+		if a.Filename == "" {
+			return 1
+		}
+		return -1
+	}
+	return cmp.Or(
+		cmp.Compare(a.Filename, b.Filename),
+		cmp.Compare(a.Line, b.Line),
+		cmp.Compare(columnOf(a.ErrorInfo), columnOf(b.ErrorInfo)),
+	)
+}
+
+// columnOf returns the column of info, or 0 if it has none.
+func columnOf(info parser.ErrorInfo) int {
+	if info.Column == nil {
+		return 0
+	}
+	return *info.Column
+}
+
+// Grouped buffers errors and writes them grouped by file on Close.
+type Grouped struct {
+	Color bool // Colorize the type by severity
+
+	w    io.Writer
+	errs []parser.CountedError
+}
+
+// NewGrouped returns a Grouped emitter writing to w.
+func NewGrouped(w io.Writer) *Grouped {
+	return &Grouped{w: w}
+}
+
+// Emit buffers info, which occurred count times.
+func (g *Grouped) Emit(info parser.ErrorInfo, count int) error {
+	g.errs = append(g.errs, parser.CountedError{ErrorInfo: info, Count: count})
+	return nil
+}
+
+// Line does nothing, grouped output only holds errors.
+func (g *Grouped) Line(string, bool) error {
+	return nil
+}
+
+// Close writes the grouped errors.
+func (g *Grouped) Close() error {
+	return writeGrouped(g.w, g.errs, g.Color)
+}
+
+// cellEscaper escapes the characters that would break a line of grouped output out of its
+// columns: tabs, which tabwriter takes for cell separators, and line breaks.
+var cellEscaper = strings.NewReplacer("\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// typeCell is the type column of a line of grouped output, colorized once the columns
+// are aligned: tabwriter would count the color codes, which differ in length, as text.
+type typeCell struct {
+	line  int // Output line of the error
+	after int // Length of the line up to the padding before the type, e.g. "  10:5"
+	text  string
+	color string
+}
+
+// writeGrouped writes errors grouped by file. Occurrence counts above one are appended as (xN).
+func writeGrouped(w io.Writer, errs []parser.CountedError, useColor bool) error {
+	errs = slices.Clone(errs)
+	slices.SortStableFunc(errs, compareGrouped)
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	var cells []typeCell
+	line := 0
+	for i, e := range errs {
+		if i == 0 || e.Filename != errs[i-1].Filename {
+			if i > 0 {
+				fmt.Fprintln(tw)
+				line++
+			}
+			name := e.Filename
+			if name == "" {
+				name = "(no file)"
+			}
+			fmt.Fprintln(tw, cellEscaper.Replace(name))
+			line++
+		}
+		loc := "-"
+		if e.Line > 0 {
+			loc = strconv.Itoa(e.Line)
+			if e.Column != nil {
+				loc += ":" + strconv.Itoa(*e.Column)
+			}
+		}
+		typ := cellEscaper.Replace(e.Type)
+		cells = append(cells, typeCell{line: line, after: len("  " + loc), text: typ, color: severityColor(e.Severity())})
+		msg := cellEscaper.Replace(e.Message)
+		if e.Count > 1 {
+			msg += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		if e.Code != "" {
+			msg += "\t" + cellEscaper.Replace(e.Code) // Like ESLint's rule name, in a column of its own
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", loc, typ, msg)
+		line++
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if !useColor {
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for _, c := range cells {
+		l := lines[c.line]
+		start := len(l) - len(strings.TrimLeft(l[c.after:], " "))
+		if c.text != "" && strings.HasPrefix(l[start:], c.text) {
+			lines[c.line] = l[:start] + colorize(c.text, c.color) + l[start+len(c.text):]
+		}
+	}
+	_, err := io.WriteString(w, strings.Join(lines, ""))
+	return err
+}
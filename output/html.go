@@ -0,0 +1,230 @@
+package output
+
+import (
+	"html/template"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- HTML Output ---
+// A self-contained HTML page for sharing results with people who don't read logs: totals
+// by severity, an index of the files with links to their sections, and for each file a
+// table of its errors, ordered like grouped output and sortable by clicking a heading.
+// Every file and error has an anchor (#file-1, #error-1) so a link can point at it.
+// The page has no external resources; it embeds its CSS and the script that sorts.
+
+// htmlReport is the data the page is rendered from.
+type htmlReport struct {
+	htmlCounts
+	Files []*htmlFile
+}
+
+// htmlCounts holds the number of errors of each severity, counting repeats.
+type htmlCounts struct {
+	Errors, Warnings, Notes int
+}
+
+// add counts an error that occurred count times.
+func (c *htmlCounts) add(sev parser.Severity, count int) {
+	switch sev {
+	case parser.SevNote:
+		c.Notes += count
+	case parser.SevWarning:
+		c.Warnings += count
+	default:
+		c.Errors += count
+	}
+}
+
+// htmlFile is the section of a file, or of the errors without one.
+type htmlFile struct {
+	htmlCounts
+	Name   string
+	Anchor string
+	Rows   []htmlRow
+}
+
+// htmlRow is one error of a file.
+type htmlRow struct {
+	Anchor   string
+	Line     int // 0 if the error has no line
+	Column   int // 0 if the error has no column
+	Location string
+	Severity string // note, warning or error, also the CSS class of the badge
+	SevRank  int    // Sort key of Severity, most severe highest
+	Type     string
+	Code     string
+	DocURL   string
+	Message  string
+	Notes    []string
+	Frames   []parser.Frame
+	Count    int
+}
+
+// HTML buffers errors and writes them as an HTML page on Close.
+type HTML struct {
+	w    io.Writer
+	errs []parser.CountedError
+}
+
+// NewHTML returns an HTML emitter writing to w.
+func NewHTML(w io.Writer) *HTML {
+	return &HTML{w: w}
+}
+
+// Emit buffers info, which occurred count times.
+func (h *HTML) Emit(info parser.ErrorInfo, count int) error {
+	h.errs = append(h.errs, parser.CountedError{ErrorInfo: info, Count: count})
+	return nil
+}
+
+// Line does nothing, the page only holds errors.
+func (h *HTML) Line(string, bool) error {
+	return nil
+}
+
+// Close writes the page.
+func (h *HTML) Close() error {
+	return htmlPage.Execute(h.w, buildHTMLReport(h.errs))
+}
+
+// buildHTMLReport groups errors by file in the order of grouped output.
+func buildHTMLReport(errs []parser.CountedError) htmlReport {
+	errs = slices.Clone(errs)
+	slices.SortStableFunc(errs, compareGrouped)
+
+	var report htmlReport
+	var file *htmlFile
+	for i, e := range errs {
+		if i == 0 || e.Filename != errs[i-1].Filename {
+			file = &htmlFile{Name: e.Filename, Anchor: "file-" + strconv.Itoa(len(report.Files)+1)}
+			if file.Name == "" {
+				file.Name = "(no file)"
+			}
+			report.Files = append(report.Files, file)
+		}
+		sev := e.Severity()
+		row := htmlRow{
+			Anchor:   "error-" + strconv.Itoa(i+1),
+			Line:     e.Line,
+			Column:   columnOf(e.ErrorInfo),
+			Location: "-",
+			Severity: sev.String(),
+			SevRank:  int(sev),
+			Type:     e.Type,
+			Code:     e.Code,
+			DocURL:   e.DocURL,
+			Message:  e.Message,
+			Notes:    e.Notes,
+			Frames:   e.Frames,
+			Count:    e.Count,
+		}
+		if e.Line > 0 {
+			row.Location = strconv.Itoa(e.Line)
+			if e.Column != nil {
+				row.Location += ":" + strconv.Itoa(*e.Column)
+			}
+		}
+		file.Rows = append(file.Rows, row)
+		file.add(sev, e.Count)
+		report.add(sev, e.Count)
+	}
+	return report
+}
+
+// htmlPage renders an htmlReport. html/template escapes every value for its context,
+// so messages can't inject markup, and unsafe DocURL schemes are replaced.
+var htmlPage = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Error report</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em; color: #1f2328; }
+h2 { font-size: 1.1em; margin-top: 2em; font-family: ui-monospace, monospace; word-break: break-all; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; vertical-align: top; padding: 0.35em 0.7em; border-bottom: 1px solid #d0d7de; }
+th { background: #f6f8fa; }
+table.sortable th { cursor: pointer; user-select: none; }
+table.sortable th[aria-sort=ascending]::after { content: " \25B2"; }
+table.sortable th[aria-sort=descending]::after { content: " \25BC"; }
+td.num { text-align: right; white-space: nowrap; }
+.message { white-space: pre-wrap; font-family: ui-monospace, monospace; }
+.notes { margin: 0.3em 0 0; padding-left: 1.2em; color: #59636e; }
+.badge { display: inline-block; padding: 0.1em 0.6em; border-radius: 1em; font-size: 0.85em; color: #fff; white-space: nowrap; }
+.badge.error { background: #cf222e; }
+.badge.warning { background: #9a6700; }
+.badge.note { background: #6e7781; }
+:target { background: #fff8c5; }
+</style>
+</head>
+<body>
+<h1>Error report</h1>
+<p>
+<span class="badge error">Errors: {{.Errors}}</span>
+<span class="badge warning">Warnings: {{.Warnings}}</span>
+<span class="badge note">Notes: {{.Notes}}</span>
+Files: {{len .Files}}
+</p>
+{{- if .Files}}
+<table class="sortable">
+<thead><tr><th>File</th><th>Errors</th><th>Warnings</th><th>Notes</th></tr></thead>
+<tbody>
+{{- range .Files}}
+<tr><td data-sort="{{.Name}}"><a href="#{{.Anchor}}">{{.Name}}</a></td><td class="num">{{.Errors}}</td><td class="num">{{.Warnings}}</td><td class="num">{{.Notes}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No errors were found.</p>
+{{- end}}
+{{- range .Files}}
+<h2 id="{{.Anchor}}">{{.Name}}</h2>
+<table class="sortable">
+<thead><tr><th>Line</th><th>Severity</th><th>Type</th><th>Code</th><th>Message</th><th>Count</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr id="{{.Anchor}}">
+<td class="num" data-sort="{{.Line}}.{{printf "%06d" .Column}}"><a href="#{{.Anchor}}">{{.Location}}</a></td>
+<td data-sort="{{.SevRank}}"><span class="badge {{.Severity}}">{{.Severity}}</span></td>
+<td>{{.Type}}</td>
+<td>{{if .DocURL}}<a href="{{.DocURL}}">{{.Code}}</a>{{else}}{{.Code}}{{end}}</td>
+<td><div class="message">{{.Message}}</div>
+{{- if .Notes}}<ul class="notes">{{range .Notes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{- if .Frames}}<details><summary>{{len .Frames}} frames</summary><ul class="notes">{{range .Frames}}<li>{{if .Function}}{{.Function}} {{end}}{{.Filename}}:{{.Line}}</li>{{end}}</ul></details>{{end -}}
+</td>
+<td class="num">{{.Count}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+<script>
+// Sorts a table by the clicked column: numbers numerically, other text alphabetically.
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (th, col) {
+    th.addEventListener("click", function () {
+      var asc = th.getAttribute("aria-sort") !== "ascending";
+      table.querySelectorAll("th").forEach(function (h) { h.removeAttribute("aria-sort"); });
+      th.setAttribute("aria-sort", asc ? "ascending" : "descending");
+      var key = function (row) {
+        var cell = row.cells[col];
+        return cell.hasAttribute("data-sort") ? cell.getAttribute("data-sort") : cell.textContent.trim();
+      };
+      var body = table.tBodies[0];
+      Array.from(body.rows).sort(function (a, b) {
+        var x = key(a), y = key(b), nx = parseFloat(x), ny = parseFloat(y);
+        var c = !isNaN(nx) && !isNaN(ny) ? nx - ny : x.localeCompare(y);
+        return asc ? c : -c;
+      }).forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,250 @@
+package output_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/festeh/errorparser/output"
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Golden Files ---
+// Each format renders the same errors and is compared with testdata/<name>.golden.
+// After an intended change to a format, rewrite the files and review the diff:
+//
+//	go test ./output -update
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func ptr(n int) *int { return &n }
+
+// counted is an error and the number of times it occurred.
+type counted struct {
+	info  parser.ErrorInfo
+	count int
+}
+
+// sampleErrors covers what the formats have to handle: a column and span, a code,
+// repeats, a Windows path, an error without a location and messages with the
+// characters CSV quotes and TSV escapes.
+func sampleErrors() []counted {
+	return []counted{
+		{parser.ErrorInfo{Filename: "src/main.rs", Line: 4, Column: ptr(5), EndLine: ptr(4), EndColumn: ptr(11), Type: "Error", Code: "E0425", Message: "cannot find value `conifg` in this scope"}, 1},
+		{parser.ErrorInfo{Filename: "pkg/store.go", Line: 31, Column: ptr(15), Type: "Warning", Code: "errcheck", Message: "Error return value of `f.Close` is not checked"}, 3},
+		{parser.ErrorInfo{Filename: `C:\src\app\Program.cs`, Line: 12, Column: ptr(9), Type: "Error", Code: "CS0103", Message: `The name "x" does not exist, in this context`}, 1},
+		{parser.ErrorInfo{Filename: "app.py", Line: 7, Type: "ValueError", Message: "bad value:\n\tsee\\docs"}, 1},
+		{parser.ErrorInfo{Type: "Panic", Message: "runtime error: index out of range [5] with length 3"}, 1},
+		{parser.ErrorInfo{Filename: "lib/util.dart", Line: 2, Column: ptr(1), Type: "Info", Message: "Unused import"}, 1},
+	}
+}
+
+// render emits errs and then lines through e, every other line matched, and closes it.
+func render(t *testing.T, e output.Emitter, errs []counted, lines ...string) {
+	t.Helper()
+	for _, c := range errs {
+		if err := e.Emit(c.info, c.count); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, line := range lines {
+		if err := e.Line(line, i%2 == 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// golden compares got with testdata/name.golden, or rewrites the file with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run go test ./output -update to create it", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from %s:\ngot:\n%s\nwant:\n%s", name, path, got, want)
+	}
+}
+
+func TestText(t *testing.T) {
+	// Text prints the whole ErrorInfo with %+v, which shows the addresses of its pointer
+	// fields, so only errors without a column or span are compared.
+	var errs []counted
+	for _, c := range sampleErrors() {
+		if c.info.Column == nil {
+			errs = append(errs, c)
+		}
+	}
+	var buf bytes.Buffer
+	text := output.NewText(&buf)
+	text.Lang = parser.LangPython
+	render(t, text, errs, "Traceback (most recent call last):", `  File "app.py", line 7, in <module>`)
+	golden(t, "text", buf.Bytes())
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	render(t, output.NewJSON(&buf), sampleErrors(), "unmatched line", "context line")
+	golden(t, "json", buf.Bytes())
+}
+
+func TestJSONCountsUnmatched(t *testing.T) {
+	var buf bytes.Buffer
+	j := output.NewJSON(&buf)
+	j.Counts, j.Unmatched = true, true
+	render(t, j, sampleErrors()[:2], "unmatched line", "context line")
+	golden(t, "json-counts-unmatched", buf.Bytes())
+}
+
+func TestJSONArray(t *testing.T) {
+	var buf bytes.Buffer
+	j := output.NewJSON(&buf)
+	j.Array = true
+	render(t, j, sampleErrors())
+	golden(t, "json-array", buf.Bytes())
+}
+
+func TestJSONArrayEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	j := output.NewJSON(&buf)
+	j.Array = true
+	render(t, j, nil, "unmatched line")
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("got %q, want %q", got, "[]\n")
+	}
+}
+
+func TestCSV(t *testing.T) {
+	var buf bytes.Buffer
+	c := output.NewCSV(&buf)
+	c.Counts = true
+	render(t, c, sampleErrors())
+	golden(t, "csv", buf.Bytes())
+}
+
+func TestCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	render(t, output.NewCSV(&buf), nil)
+	if got, want := buf.String(), "filename,line,column,type,code,message\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTSV(t *testing.T) {
+	var buf bytes.Buffer
+	tsv := output.NewTSV(&buf)
+	tsv.Counts = true
+	render(t, tsv, sampleErrors())
+	golden(t, "tsv", buf.Bytes())
+}
+
+func TestSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	render(t, output.NewSARIF(&buf), sampleErrors())
+	golden(t, "sarif", buf.Bytes())
+}
+
+func TestSARIFEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	render(t, output.NewSARIF(&buf), nil)
+	golden(t, "sarif-empty", buf.Bytes())
+}
+
+func TestGrouped(t *testing.T) {
+	var buf bytes.Buffer
+	render(t, output.NewGrouped(&buf), sampleErrors())
+	golden(t, "grouped", buf.Bytes())
+}
+
+// ansiCode matches the color codes of the output.
+var ansiCode = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestGroupedColor(t *testing.T) {
+	// A file with a note, a warning and an error, whose color codes differ in length
+	errs := append(sampleErrors(),
+		counted{parser.ErrorInfo{Filename: "lib/util.dart", Line: 14, Column: ptr(3), Type: "Warning", Message: "Dead code"}, 1},
+		counted{parser.ErrorInfo{Filename: "lib/util.dart", Line: 20, Type: "Error", Code: "undefined_identifier", Message: "Undefined name 'x'"}, 1},
+	)
+	var plain, colored bytes.Buffer
+	render(t, output.NewGrouped(&plain), errs)
+	g := output.NewGrouped(&colored)
+	g.Color = true
+	render(t, g, errs)
+	golden(t, "grouped-color", colored.Bytes())
+	// The color codes, which differ in length by severity, must not shift the columns
+	if stripped := ansiCode.ReplaceAll(colored.Bytes(), nil); !bytes.Equal(stripped, plain.Bytes()) {
+		t.Errorf("colored output is aligned differently:\n%s\nwant:\n%s", stripped, plain.Bytes())
+	}
+}
+
+// recorder is an Emitter that records what it is given.
+type recorder struct {
+	got    []string
+	closed bool
+}
+
+func (r *recorder) Emit(info parser.ErrorInfo, count int) error {
+	r.got = append(r.got, info.Filename+" "+info.Type+" "+info.Message)
+	return nil
+}
+
+func (r *recorder) Line(line string, matched bool) error {
+	r.got = append(r.got, "line "+line)
+	return nil
+}
+
+func (r *recorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestSorted(t *testing.T) {
+	errs := []counted{
+		{parser.ErrorInfo{Type: "Panic", Message: "no file"}, 1},
+		{parser.ErrorInfo{Filename: "b.go", Line: 3, Type: "Error", Message: "later file"}, 1},
+		{parser.ErrorInfo{Filename: "a.go", Line: 10, Column: ptr(2), Type: "Error", Message: "column 2"}, 1},
+		{parser.ErrorInfo{Filename: "a.go", Line: 10, Type: "Error", Message: "no column"}, 1},
+		{parser.ErrorInfo{Filename: "a.go", Line: 2, Type: "Warning", Message: "warning"}, 1},
+		{parser.ErrorInfo{Filename: "a.go", Line: 2, Type: "note", Message: "note"}, 1},
+		{parser.ErrorInfo{Filename: "a.go", Line: 2, Type: "Error", Message: "b"}, 1},
+		{parser.ErrorInfo{Filename: "a.go", Line: 2, Type: "Error", Message: "a"}, 1},
+	}
+	var r recorder
+	render(t, output.NewSorted(&r), errs, "first", "second")
+	want := []string{
+		"a.go Error a",
+		"a.go Error b",
+		"a.go Warning warning",
+		"a.go note note",
+		"a.go Error no column",
+		"a.go Error column 2",
+		"b.go Error later file",
+		" Panic no file",
+		"line first",
+		"line second",
+	}
+	if len(r.got) != len(want) {
+		t.Fatalf("got %q, want %q", r.got, want)
+	}
+	for i := range want {
+		if r.got[i] != want[i] {
+			t.Errorf("position %d: got %q, want %q", i, r.got[i], want[i])
+		}
+	}
+	if !r.closed {
+		t.Error("Sorted didn't close the Emitter it writes to")
+	}
+}
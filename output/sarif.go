@@ -0,0 +1,141 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- SARIF Output ---
+// Minimal SARIF 2.1.0 document, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+// Only the properties needed for code scanning uploads are emitted.
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int  `json:"startLine"`
+	StartColumn *int `json:"startColumn,omitempty"`
+	EndLine     *int `json:"endLine,omitempty"`
+	EndColumn   *int `json:"endColumn,omitempty"`
+}
+
+// sarifLevel maps a severity onto the SARIF result levels, which share its names.
+func sarifLevel(sev parser.Severity) string {
+	return sev.String()
+}
+
+// toSARIFResult converts a parsed error into a SARIF result.
+func toSARIFResult(info parser.ErrorInfo) sarifResult {
+	result := sarifResult{
+		RuleID:  info.Code,
+		Level:   sarifLevel(info.Severity()),
+		Message: sarifMessage{Text: info.Message},
+	}
+	if info.Filename == "" {
+		return result // Errors without a location (e.g. bare Python exceptions) have no physicalLocation
+	}
+	loc := sarifPhysicalLocation{
+		// URIs always use forward slashes, also for Windows paths like C:\src\main.go
+		ArtifactLocation: sarifArtifactLocation{URI: strings.ReplaceAll(info.Filename, `\`, "/")},
+	}
+	if info.Line > 0 { // SARIF lines are 1-based, so 0 means unknown
+		loc.Region = &sarifRegion{
+			StartLine:   info.Line,
+			StartColumn: info.Column,
+			EndLine:     info.EndLine,
+			EndColumn:   info.EndColumn,
+		}
+	}
+	result.Locations = []sarifLocation{{PhysicalLocation: loc}}
+	return result
+}
+
+// SARIF buffers errors and writes them as a single SARIF document on Close.
+type SARIF struct {
+	w       io.Writer
+	results []sarifResult
+}
+
+// NewSARIF returns a SARIF emitter writing to w.
+func NewSARIF(w io.Writer) *SARIF {
+	return &SARIF{w: w}
+}
+
+// Emit buffers info as a SARIF result. SARIF has no occurrence counts, so count is ignored.
+func (s *SARIF) Emit(info parser.ErrorInfo, count int) error {
+	s.results = append(s.results, toSARIFResult(info))
+	return nil
+}
+
+// Line does nothing, SARIF only holds errors.
+func (s *SARIF) Line(string, bool) error {
+	return nil
+}
+
+// Close writes the SARIF document.
+func (s *SARIF) Close() error {
+	return writeSARIF(s.w, s.results)
+}
+
+// writeSARIF writes a single SARIF document containing all results to w.
+func writeSARIF(w io.Writer, results []sarifResult) error {
+	if results == nil {
+		results = []sarifResult{} // The schema requires an array, not null
+	}
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "errorparser"}},
+			Results: results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,72 @@
+package output
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Sorted Output ---
+// Sorted passes errors on to another format in an order that doesn't depend on the
+// order of the input, so output can be compared against golden files: by file name,
+// line, column, then severity, most severe first. Errors without a file come last.
+// Remaining ties are broken by type, code and message.
+
+// compareSorted orders errors for Sorted.
+func compareSorted(a, b parser.CountedError) int {
+	return cmp.Or(
+		compareGrouped(a, b),
+		cmp.Compare(b.Severity(), a.Severity()),
+		cmp.Compare(a.Type, b.Type),
+		cmp.Compare(a.Code, b.Code),
+		cmp.Compare(a.Message, b.Message),
+	)
+}
+
+// sortedLine is an input line buffered by Sorted.
+type sortedLine struct {
+	line    string
+	matched bool
+}
+
+// Sorted buffers the errors and lines for another Emitter and writes them to it
+// sorted on Close. Lines have no file, so they follow the errors in input order.
+type Sorted struct {
+	e     Emitter
+	errs  []parser.CountedError
+	lines []sortedLine
+}
+
+// NewSorted returns a Sorted emitter writing to e.
+func NewSorted(e Emitter) *Sorted {
+	return &Sorted{e: e}
+}
+
+// Emit buffers info, which occurred count times.
+func (s *Sorted) Emit(info parser.ErrorInfo, count int) error {
+	s.errs = append(s.errs, parser.CountedError{ErrorInfo: info, Count: count})
+	return nil
+}
+
+// Line buffers line.
+func (s *Sorted) Line(line string, matched bool) error {
+	s.lines = append(s.lines, sortedLine{line, matched})
+	return nil
+}
+
+// Close writes the sorted errors, then the lines, and closes the underlying Emitter.
+func (s *Sorted) Close() error {
+	slices.SortStableFunc(s.errs, compareSorted)
+	for _, e := range s.errs {
+		if err := s.e.Emit(e.ErrorInfo, e.Count); err != nil {
+			return err
+		}
+	}
+	for _, l := range s.lines {
+		if err := s.e.Line(l.line, l.matched); err != nil {
+			return err
+		}
+	}
+	return s.e.Close()
+}
@@ -0,0 +1,57 @@
+package output
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- TSV Output ---
+// One tab-separated row per error with the columns of the CSV output, but no header,
+// so awk and cut can rely on field positions. An absent column is an empty field.
+// With InputLine an input line field is appended, and with Counts a count field.
+
+// tsvEscaper escapes the characters that would break a row apart. Backslashes are
+// escaped too, so "\t" in a field always stands for a tab.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// TSV writes errors as tab-separated rows.
+type TSV struct {
+	Counts    bool // Append a count field, for deduplicated results
+	InputLine bool // Append an input line field with ErrorInfo.InputLine
+
+	w io.Writer
+}
+
+// NewTSV returns a TSV emitter writing to w.
+func NewTSV(w io.Writer) *TSV {
+	return &TSV{w: w}
+}
+
+// Emit writes the row for info.
+func (t *TSV) Emit(info parser.ErrorInfo, count int) error {
+	record := csvRecord(info)
+	if t.InputLine {
+		record = append(record, strconv.Itoa(info.InputLine))
+	}
+	if t.Counts {
+		record = append(record, strconv.Itoa(count))
+	}
+	for i, field := range record {
+		record[i] = tsvEscaper.Replace(field)
+	}
+	_, err := io.WriteString(t.w, strings.Join(record, "\t")+"\n")
+	return err
+}
+
+// Line does nothing, TSV only holds errors.
+func (t *TSV) Line(string, bool) error {
+	return nil
+}
+
+// Close does nothing, every row is written by Emit.
+func (t *TSV) Close() error {
+	return nil
+}
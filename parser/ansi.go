@@ -0,0 +1,38 @@
+package parser
+
+import "strings"
+
+// --- ANSI Escape Handling ---
+// Colorized compiler output (rustc, cargo, clang) wraps tokens in CSI sequences
+// such as "\x1b[1m\x1b[31merror\x1b[0m", which splits paths and words in the lexer.
+
+const escape = '\x1b'
+
+// StripANSI removes ANSI CSI escape sequences (ESC '[' parameters intermediates final)
+// from s. Only real ESC bytes start a sequence, so text that merely looks like an
+// escape, such as a literal `\x1b[0m` or "[0m" inside a quoted message, is kept.
+func StripANSI(s string) string {
+	if strings.IndexByte(s, escape) < 0 {
+		return s // Fast path: nothing to strip
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != escape || i+1 >= len(s) || s[i+1] != '[' {
+			sb.WriteByte(s[i])
+			continue
+		}
+		// Skip parameter bytes (0x30-0x3F) and intermediate bytes (0x20-0x2F)
+		j := i + 2
+		for j < len(s) && s[j] >= 0x20 && s[j] <= 0x3F {
+			j++
+		}
+		if j < len(s) && s[j] >= 0x40 && s[j] <= 0x7E {
+			i = j // Skip the final byte too
+			continue
+		}
+		// Not a complete CSI sequence, keep it verbatim
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
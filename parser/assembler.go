@@ -0,0 +1,1064 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// --- Multi-line Reassembly ---
+// Some diagnostics span several physical lines: Python prints `File "..."` references
+// before the error line, Rust prints the `-->` location, source excerpt and notes after
+// the message line,
+// `go test` prints assertion lines after the `--- FAIL` header naming the test, and
+// Go panics, Java exceptions and Node.js errors are followed by their stack frames.
+// Lines printed by make around the output of the tools it runs are recognized whatever
+// the language, see MakeParseResult.
+// Assembler carries that context between lines and emits complete ErrorInfo values.
+
+// Assembler reassembles multi-line errors for a single input stream.
+// It holds per-stream state, so use one Assembler per input; separate Assemblers
+// can be used concurrently.
+//
+// Completed errors record the 1-based number of the input line they came from in
+// InputLine: the first line of an error followed by continuation lines (a Go panic's
+// "panic:" line, a Rust message line), or the line that completes one that starts
+// with context (the error line after Python's File lines).
+type Assembler struct {
+	lang Language
+
+	pythonTrace   PythonTraceback // Python `File "..."` frames waiting for the error line
+	pythonRaw     []string        // Input lines of the frames in pythonTrace
+	rustPending   *ErrorInfo      // Rust diagnostic collecting its location, underline and notes
+	rustPanic     *RustPanic      // Rust panic collecting its message, notes and backtrace
+	goTest        *GoTestFailure  // Failing Go test whose assertion lines may follow
+	goTestSeen    bool            // Whether goTest already produced an error from an assertion line
+	goEventTest   string          // Test of the go -json event whose output is being fed, see feedGoJSON
+	goTestsLogged map[string]bool // Tests whose assertion lines came before their --- FAIL header
+	goPanic       *GoPanic        // Go panic collecting its stack frames
+	goInStack     bool            // Whether the goroutine header of goPanic was seen
+	goCall        string          // Function line of the stack frame whose location comes next
+	goPackage     string          // Package named by the latest "# pkg" header, attached to compile errors
+	goRace        *GoRace         // Data race report collecting its accesses and their stacks
+	goLintLines   int             // Excerpt lines (source and caret) that may follow a golangci-lint finding
+	goPending     *ErrorInfo      // Go compile or module error collecting its indented continuation lines
+	javaPending   *JavaException  // Java exception collecting its frames and causes
+	javaCause     *JavaCause      // Latest "Caused by:" of javaPending, receiving the frames that follow
+	nodePending   *NodeError      // Node.js error collecting its frames
+	elixirWarn    *ErrorInfo      // Elixir warning still waiting for its location line
+	haskellErr    *HaskellError   // GHC diagnostic collecting its indented continuation lines
+	dartAwait     bool            // Whether a Dart "Unhandled exception:" header waits for its exception line
+	dartPending   *DartException  // Dart exception collecting its frames
+	tfPending     *ErrorInfo      // Terraform diagnostic collecting its location and details
+	tfParagraph   bool            // Whether the next Terraform detail line starts a new note
+	cljPending    *ClojureError   // Clojure error header waiting for its message line
+	wpPending     *ErrorInfo      // Webpack error header collecting its message and detail lines
+	mlLoc         *OCamlLocation  // OCaml location header waiting for its message line
+	mlPending     *ErrorInfo      // OCaml diagnostic collecting its wrapped message lines and hints
+	luaPending    *LuaError       // Lua error collecting the frames of its traceback
+	rubyPending   *RubyError      // Ruby error collecting the `from` frames of its backtrace
+	android       bool            // Java output of an Android process logged by logcat, see feedLogcat
+	androidApp    string          // Package of the Android app, from logcat's "Process:" line
+	androidThread string          // Thread of the latest logcat "FATAL EXCEPTION:" line, for the exception that follows
+	raw           []string        // Input lines of the pending error, see takePending
+	rawLine       int             // Input line number of the first line in raw
+	lineNo        int             // Input line number of the latest line fed
+
+	all         []*Assembler          // One Assembler per built-in language with LangAll, see feedAll
+	goJSON      map[string]*Assembler // Go Assembler per package with LangGoJSON, see feedGoJSON
+	goJSONOrder []string              // Packages of goJSON in order of appearance, for Flush
+	logcat      map[int]*Assembler    // Java Assembler per process with LangLogcat, see feedLogcat
+	logcatOrder []int                 // Processes of logcat in order of appearance, for Flush
+	noTrim      bool                  // Keep the whitespace around messages, see SetNoTrim
+	maxFrames   int                   // Stack frames kept per Go panic, see SetMaxFrames
+}
+
+// NewAssembler returns an Assembler for the given language.
+// With LangAll every built-in language keeps its own multi-line context and each
+// line goes to the first language (in detection order) whose grammar matches it.
+func NewAssembler(lang Language) *Assembler {
+	a := &Assembler{lang: lang}
+	if lang == LangAll {
+		for _, l := range detectableLanguages {
+			a.all = append(a.all, NewAssembler(l))
+		}
+	}
+	return a
+}
+
+// SetNoTrim makes the Assembler keep the leading and trailing whitespace (and a
+// trailing "\r") of messages as it appears in the input, for tools that compare
+// exact message bytes. By default messages are trimmed.
+func (a *Assembler) SetNoTrim(noTrim bool) {
+	a.noTrim = noTrim
+}
+
+// SetMaxFrames limits the stack frames kept for each Go panic to n, so deep stacks
+// don't bloat the output; 0 means no limit. The innermost frames are kept and the
+// number of dropped ones is reported in OmittedFrames. The top user frame, which gives
+// the panic its location, is always kept.
+func (a *Assembler) SetMaxFrames(n int) {
+	a.maxFrames = n
+	for _, sub := range a.all {
+		sub.SetMaxFrames(n)
+	}
+	for _, sub := range a.goJSON {
+		sub.SetMaxFrames(n)
+	}
+}
+
+// Feed parses the next input line. It returns the errors completed by this line
+// (possibly ones started on earlier lines) and whether the line matched the
+// language grammar. Empty lines never match.
+func (a *Assembler) Feed(line string) ([]ErrorInfo, bool) {
+	infos, matched := a.feedAt(line, a.lineNo+1)
+	return a.untrim(infos), matched
+}
+
+// SkipLines tells the Assembler that n input lines were skipped without being fed,
+// so that InputLine keeps counting physical lines.
+func (a *Assembler) SkipLines(n int) {
+	a.lineNo += n
+}
+
+// feedAt feeds line as input line n and records n as the InputLine of the errors it
+// completes, unless they started on an earlier line.
+func (a *Assembler) feedAt(line string, n int) ([]ErrorInfo, bool) {
+	a.lineNo = n
+	infos, matched := a.feed(line)
+	return a.atLine(infos), matched
+}
+
+// atLine records the current input line as the InputLine of infos that have none.
+func (a *Assembler) atLine(infos []ErrorInfo) []ErrorInfo {
+	for i := range infos {
+		if infos[i].InputLine == 0 {
+			infos[i].InputLine = a.lineNo
+		}
+	}
+	return infos
+}
+
+// startRaw starts the input lines of a new pending error with line.
+func (a *Assembler) startRaw(line string) {
+	a.raw = []string{line}
+	a.rawLine = a.lineNo
+}
+
+// addRaw adds line to the input lines of the pending error, starting them if needed.
+func (a *Assembler) addRaw(line string) {
+	if len(a.raw) == 0 {
+		a.rawLine = a.lineNo
+	}
+	a.raw = append(a.raw, line)
+}
+
+// untrim applies SetNoTrim to completed errors.
+func (a *Assembler) untrim(infos []ErrorInfo) []ErrorInfo {
+	if a.noTrim {
+		for i := range infos {
+			infos[i].Message = untrimMessage(infos[i])
+		}
+	}
+	return infos
+}
+
+// feed does the work of Feed.
+func (a *Assembler) feed(line string) ([]ErrorInfo, bool) {
+	if a.all != nil {
+		return a.feedAll(line)
+	}
+	if a.lang == LangGoJSON {
+		return a.feedGoJSON(line)
+	}
+	if a.lang == LangLogcat {
+		return a.feedLogcat(line)
+	}
+	if line == "" {
+		if a.goPanic != nil && !a.goInStack {
+			return nil, false // Blank line between the panic message and its goroutine header
+		}
+		if a.goRace != nil {
+			return nil, false // Blank line between the blocks of a race report
+		}
+		if a.dartPending != nil && len(a.dartPending.Frames) == 0 {
+			return nil, false // Blank line between the Dart exception message and its frames
+		}
+		if a.tfPending != nil && a.tfPending.Filename == "" {
+			return nil, false // Blank line between a Terraform summary and its location
+		}
+		return a.takePending(), false
+	}
+
+	// make frames the output of the tools it runs, so its lines are recognized for every language
+	if mk, ok := parseMakeLine(line); ok {
+		out := a.takePending()
+		switch {
+		case mk.Failure != nil:
+			out = append(out, withRaw(mk.Failure.ToErrorInfo(), line))
+		case mk.Makefile != nil:
+			out = append(out, withRaw(mk.Makefile.ToErrorInfo(), line))
+		}
+		return out, true
+	}
+
+	parsed, err := ParseLine(line, a.lang)
+	if err != nil {
+		return a.takePending(), false
+	}
+
+	if _, ok := parsed.(*UnmatchedLine); ok && (a.goInStack || a.goRace != nil) && isGoStackCall(line) {
+		a.goCall = strings.TrimSpace(line) // Function line of the next stack frame
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && (a.goLintLines == 2 || a.goLintLines == 1 && isCaretLine(line)) {
+		a.goLintLines-- // Source line or caret under a golangci-lint finding
+		return nil, true
+	}
+	// The toolchain prints a column with every compile error it continues, unlike e.g. the
+	// Ruby errors followed by indented "from" lines that parse as column-less compile errors
+	if _, ok := parsed.(*UnmatchedLine); ok && a.goPending != nil && (a.goPending.Column != nil || a.goPending.Type == "ModuleError") && a.goLintLines == 0 && isContinuationLine(line) {
+		a.goPending.Notes = append(a.goPending.Notes, strings.TrimSpace(line)) // e.g. the "have (int)" and "want (string)" of a call
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.rustPending != nil && len(a.rustPending.Notes) > 0 && strings.HasPrefix(line, " ") {
+		a.rustPending.Notes[len(a.rustPending.Notes)-1] += " " + strings.TrimSpace(line) // Wrapped note
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.rustPanic != nil && a.rustPanic.addLine(line) {
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.dartPending != nil && len(a.dartPending.Frames) == 0 {
+		return nil, false // Rest of the exception message, e.g. the input a FormatException points at
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.cljPending != nil {
+		a.cljPending.Message = strings.TrimSpace(line) // Message line under a Clojure error header
+		info := withRaw(a.cljPending.ToErrorInfo(), strings.Join(append(a.raw, line), "\n"))
+		a.cljPending, a.raw = nil, nil
+		return []ErrorInfo{info}, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.wpPending != nil {
+		if a.wpPending.Message == "" {
+			a.wpPending.Message = strings.TrimSpace(line) // e.g. Module not found: Error: Can't resolve ...
+		}
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.tfPending != nil {
+		if text, ok := terraformDetail(line); ok {
+			a.addTerraformDetail(text)
+			a.addRaw(line)
+			return nil, true
+		}
+		a.addRaw(line)
+		return nil, true // Source excerpt of the diagnostic
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.mlPending != nil && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "Hint: ")) {
+		if strings.HasPrefix(line, " ") {
+			a.mlPending.Message += " " + strings.TrimSpace(line) // Wrapped message
+		} else {
+			a.mlPending.Notes = append(a.mlPending.Notes, line)
+		}
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.luaPending != nil && isLuaTailCalls(line) {
+		a.addRaw(line)
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.mlLoc != nil {
+		a.addRaw(line) // Source excerpt under an OCaml location
+		return nil, true
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && len(a.pythonTrace.Frames) > 0 {
+		a.pythonTrace.addExcerpt(line) // Code snippet or caret line, which locates the column
+		return nil, false
+	}
+	if _, ok := parsed.(*UnmatchedLine); ok && a.haskellErr != nil && isHaskellContinuation(line) {
+		a.haskellErr.addDetail(line)
+		a.addRaw(line)
+		return nil, true
+	}
+
+	switch v := parsed.(type) {
+	case *RustParseResult:
+		return a.feedRust(v, line)
+	case *PythonParseResult:
+		if v.FileRef != nil {
+			// Frames survive code snippet, caret and blank lines until an error line consumes them
+			a.pythonTrace.addFrame(*v.FileRef)
+			a.pythonRaw = append(a.pythonRaw, line)
+			return nil, true
+		}
+		if v.Pytest != nil {
+			return []ErrorInfo{withRaw(v.Pytest.ToErrorInfo(), line)}, true
+		}
+		if v.Error != nil {
+			a.pythonTrace.Error = v.Error
+			info := withRaw(a.pythonTrace.ToErrorInfo(), strings.Join(append(a.pythonRaw, line), "\n"))
+			a.pythonTrace, a.pythonRaw = PythonTraceback{}, nil
+			return []ErrorInfo{info}, true
+		}
+	case *GoParseResult:
+		return a.feedGo(v, line)
+	case *JavaParseResult:
+		return a.feedJava(v, line), true
+	case *NodeParseResult:
+		if v.Frame != nil && a.nodePending != nil {
+			a.nodePending.Frames = append(a.nodePending.Frames, *v.Frame)
+			a.addRaw(line)
+			return nil, true
+		}
+		out := a.takePending()
+		if v.Error != nil {
+			a.nodePending = v.Error
+			a.startRaw(line)
+		}
+		return out, true
+	case *FlutterParseResult:
+		return a.feedFlutter(v, line)
+	case *TypeScriptError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *CppDiagnostic:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *PHPError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *KotlinParseResult:
+		if v.Gradle != nil {
+			return []ErrorInfo{withRaw(v.Gradle.ToErrorInfo(), line)}, true
+		}
+		return []ErrorInfo{withRaw(v.Error.ToErrorInfo(), line)}, true
+	case *MavenError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *BazelError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *MSVCError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *CSharpError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *SwiftError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *ElixirParseResult:
+		return a.feedElixir(v, line)
+	case *HaskellError:
+		out := a.takePending()
+		a.haskellErr = v
+		a.startRaw(line)
+		return out, true
+	case *TerraformParseResult:
+		return a.feedTerraform(v, line), true
+	case *ClojureParseResult:
+		out := a.takePending()
+		if v.Error != nil {
+			a.cljPending = v.Error
+			a.startRaw(line)
+		} else {
+			out = append(out, withRaw(v.Exception.ToErrorInfo(), line))
+		}
+		return out, true
+	case *WebpackHeader:
+		out := a.takePending()
+		info := v.ToErrorInfo()
+		a.wpPending = &info
+		a.startRaw(line)
+		return out, true
+	case *PerlError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *OCamlParseResult:
+		return a.feedOCaml(v, line), true
+	case *LuaParseResult:
+		return a.feedLua(v, line)
+	case *CustomError:
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *ScalaLine:
+		if v.Location == nil {
+			return nil, true // Snippet, caret or summary line of a diagnostic
+		}
+		return []ErrorInfo{withRaw(v.ToErrorInfo(), line)}, true
+	case *RubyParseResult:
+		return a.feedRuby(v, line), true
+	}
+	return a.takePending(), false
+}
+
+// feedOCaml handles a parsed line of OCaml output. A location header waits for its
+// message line, and the message for its wrapped continuation lines and hints.
+func (a *Assembler) feedOCaml(v *OCamlParseResult, line string) []ErrorInfo {
+	if v.Location != nil {
+		out := a.takePending()
+		a.mlLoc = v.Location
+		a.startRaw(line)
+		return out
+	}
+	var out []ErrorInfo
+	if a.mlLoc == nil {
+		out = a.takePending() // Message without a location, e.g. a linker error
+	}
+	info := v.Message.ToErrorInfo(a.mlLoc)
+	a.mlLoc, a.mlPending = nil, &info
+	a.addRaw(line)
+	return out
+}
+
+// feedLua handles a parsed line of Lua output and reports whether it matched.
+// The traceback header and frames only match after an error line.
+func (a *Assembler) feedLua(v *LuaParseResult, line string) ([]ErrorInfo, bool) {
+	if v.Error != nil {
+		out := a.takePending()
+		a.luaPending = v.Error
+		a.startRaw(line)
+		return out, true
+	}
+	if a.luaPending == nil {
+		return a.takePending(), false
+	}
+	if v.Frame != nil {
+		a.luaPending.Frames = append(a.luaPending.Frames, *v.Frame)
+	}
+	a.addRaw(line)
+	return nil, true
+}
+
+// feedRuby handles a parsed line of Ruby output. An exception raised in a method collects
+// the `from` lines of its backtrace that follow; warnings and other errors have none and
+// are complete. A `from` line without an exception still matches.
+func (a *Assembler) feedRuby(v *RubyParseResult, line string) []ErrorInfo {
+	if v.Frame != nil && a.rubyPending != nil {
+		a.rubyPending.Frames = append(a.rubyPending.Frames, *v.Frame)
+		a.addRaw(line)
+		return nil
+	}
+	out := a.takePending()
+	switch {
+	case v.Error != nil && v.Error.Method != "":
+		a.rubyPending = v.Error
+		a.startRaw(line)
+	case v.Error != nil:
+		out = append(out, withRaw(v.Error.ToErrorInfo(), line))
+	}
+	return out
+}
+
+// withRaw sets the input line of a single-line error.
+func withRaw(info ErrorInfo, line string) ErrorInfo {
+	info.Raw = line
+	return info
+}
+
+// feedGo handles a parsed line of Go output and reports whether it matched.
+// The access and goroutine lines of a race report only match inside one.
+func (a *Assembler) feedGo(v *GoParseResult, line string) ([]ErrorInfo, bool) {
+	if out, ok := a.feedGoRace(v, line); ok {
+		return out, true
+	}
+	if v.RaceAccess != nil || v.RaceGoroutine != nil {
+		return a.takePending(), false
+	}
+	return a.feedGoLine(v, line), true
+}
+
+// feedGoRace handles the lines of a data race report and reports whether line was one.
+func (a *Assembler) feedGoRace(v *GoParseResult, line string) ([]ErrorInfo, bool) {
+	switch {
+	case v.RaceHeader != nil:
+		out := a.takePending()
+		a.goRace = &GoRace{Pos: v.RaceHeader.Pos}
+		a.startRaw(line)
+		return out, true
+	case v.RaceEnd:
+		return a.takePending(), true // Closes a report, or opens the next one
+	case a.goRace == nil:
+		return nil, false
+	case v.RaceAccess != nil:
+		a.goRace.Accesses = append(a.goRace.Accesses, *v.RaceAccess)
+		a.goRace.inAccess = true
+	case v.RaceGoroutine != nil:
+		a.goRace.inAccess = false
+	case v.StackLocation != nil:
+		if n := len(a.goRace.Accesses); n > 0 && a.goRace.inAccess {
+			access := &a.goRace.Accesses[n-1]
+			access.Frames = append(access.Frames, GoStackFrame{
+				Function: a.goCall,
+				File:     v.StackLocation.File,
+				Line:     v.StackLocation.Line,
+				Offset:   v.StackLocation.Offset,
+			})
+		}
+		a.goCall = ""
+	default:
+		return nil, false
+	}
+	a.addRaw(line)
+	return nil, true
+}
+
+// feedGoLine handles a parsed line of Go output outside a race report.
+func (a *Assembler) feedGoLine(v *GoParseResult, line string) []ErrorInfo {
+	switch {
+	case v.Link != nil && v.Link.Context:
+		return nil
+	case v.Link != nil:
+		return []ErrorInfo{withRaw(v.Link.ToErrorInfo(), line)}
+	case v.TestAssertion != nil:
+		info := withRaw(v.TestAssertion.ToErrorInfo(), line)
+		switch {
+		case a.goTest != nil && (a.goEventTest == "" || a.goEventTest == a.goTest.Name):
+			info.Message = a.goTest.Name + ": " + info.Message
+			info.Raw = strings.Join(a.raw, "\n") + "\n" + line
+			a.goTestSeen = true
+		case a.goEventTest != "":
+			// go test -json runs tests with -v, which prints the assertion lines of a
+			// test as they happen, before its --- FAIL header
+			info.Message = a.goEventTest + ": " + info.Message
+			if a.goTestsLogged == nil {
+				a.goTestsLogged = map[string]bool{}
+			}
+			a.goTestsLogged[a.goEventTest] = true
+		}
+		return []ErrorInfo{info}
+	case v.Goroutine != nil && a.goPanic != nil && !a.goInStack:
+		a.goInStack = true // Stack of the panicking goroutine starts
+		id := v.Goroutine.ID
+		a.goPanic.Goroutine = &id
+		a.addRaw(line)
+		return nil
+	case v.Signal != nil && a.goPanic != nil && !a.goInStack:
+		a.goPanic.Signal = "signal" + strings.TrimSuffix(strings.TrimRight(string(v.Signal.Text), " "), "]")
+		a.addRaw(line)
+		return nil
+	case v.Package != nil:
+		a.goPackage = string(v.Package.Package) // Context for the compile errors that follow
+		return a.takePending()
+	case v.StackLocation != nil:
+		if a.goInStack {
+			a.addRaw(line)
+			a.goPanic.addFrame(GoStackFrame{
+				Function: a.goCall,
+				File:     v.StackLocation.File,
+				Line:     v.StackLocation.Line,
+				Offset:   v.StackLocation.Offset,
+			}, a.maxFrames)
+			a.goCall = ""
+		}
+		return nil
+	}
+
+	if v.TestFailure != nil && a.goTest != nil && strings.HasPrefix(v.TestFailure.Name, a.goTest.Name+"/") {
+		a.goTestSeen = true // The failing subtest reports the parent's failure
+	}
+	out := a.takePending()
+	switch {
+	case v.CompileError != nil:
+		c := v.CompileError
+		if a.goPackage != "" {
+			c = c.withoutCheck() // Compiler or go vet output, which names no linter
+		}
+		info := c.ToErrorInfo()
+		info.Package = a.goPackage
+		a.goPending = &info
+		a.startRaw(line)
+		if c.isGolangciLint() {
+			a.goLintLines = 2
+		}
+	case v.Module != nil:
+		a.goPackage = "" // The go command reports it, not a package build
+		info := v.Module.ToErrorInfo()
+		a.goPending = &info
+		a.startRaw(line)
+	case v.ModuleNotice != nil:
+		a.goPackage = ""
+	case v.Cgo != nil:
+		info := withRaw(v.Cgo.ToErrorInfo(), line)
+		info.Package = a.goPackage
+		out = append(out, info)
+	case v.Panic != nil:
+		a.goPackage = ""
+		a.goPanic = v.Panic
+		a.startRaw(line)
+	case v.TestFailure != nil:
+		a.goPackage = ""
+		a.goTest = v.TestFailure
+		a.goTestSeen = a.goTestsLogged[v.TestFailure.Name] // Already reported by its assertions
+		a.startRaw(line)
+	}
+	return out
+}
+
+// feedRust handles a parsed line of Rust output and reports whether it matched.
+// A diagnostic stays pending until the next message or a blank line, collecting its
+// location, underline and notes. Excerpt and note lines only match inside a diagnostic,
+// as other compilers (GCC, GHC) draw similar excerpts.
+func (a *Assembler) feedRust(v *RustParseResult, line string) ([]ErrorInfo, bool) {
+	if p := a.rustPanic; p != nil && v.Note != nil && !p.open {
+		p.awaiting = false // e.g. "note: run with `RUST_BACKTRACE=1` ...", which ends the message
+		p.Notes = append(p.Notes, v.Note.String())
+		a.addRaw(line)
+		return nil, true
+	}
+	if p := a.rustPanic; p != nil && p.open {
+		p.addLine(line) // Message line of the old layout that looks like Rust output
+		a.addRaw(line)
+		return nil, true
+	}
+	if info := a.rustPending; info != nil && v.Message == nil {
+		a.addRaw(line)
+		switch {
+		case v.Location != nil && info.Filename == "":
+			var loc ErrorInfo // Later `-->` lines belong to notes and don't move the error
+			v.Location.applyTo(&loc)
+			*info = info.MergedWith(loc)
+		case v.Note != nil:
+			info.Notes = append(info.Notes, v.Note.String())
+		case v.Gutter != nil && info.Column != nil && info.EndColumn == nil:
+			// The primary underline starting at the error column gives the end of the span
+			if start, end, label, ok := v.Gutter.underline(); ok && start == *info.Column {
+				line := info.Line
+				info.EndLine, info.EndColumn = &line, &end
+				if label != "" {
+					info.Notes = append(info.Notes, label)
+				}
+			}
+		}
+		return nil, true
+	}
+	out := a.takePending()
+	if v.Message != nil {
+		info := v.Message.ToErrorInfo()
+		a.rustPending = &info
+		a.startRaw(line)
+	}
+	if v.Panic != nil {
+		a.rustPanic = v.Panic
+		a.startRaw(line)
+	}
+	return out, v.Message != nil || v.Location != nil || v.Panic != nil
+}
+
+// feedFlutter handles a parsed line of Flutter/Dart output and reports whether it matched.
+// An exception line only matches right after an "Unhandled exception:" header, and
+// frames only inside an exception, as "Type: message" alone is far too common.
+func (a *Assembler) feedFlutter(v *FlutterParseResult, line string) ([]ErrorInfo, bool) {
+	switch {
+	case v.Frame != nil && a.dartPending != nil:
+		a.dartPending.Frames = append(a.dartPending.Frames, *v.Frame)
+		a.addRaw(line)
+		return nil, true
+	case v.Exception != nil && a.dartAwait:
+		a.dartAwait = false
+		a.dartPending = v.Exception
+		a.addRaw(line)
+		return nil, true
+	}
+	out := a.takePending()
+	switch {
+	case v.Error != nil:
+		return append(out, withRaw(v.Error.ToErrorInfo(), line)), true
+	case v.Unhandled != nil:
+		a.startRaw(line)
+		if msg := strings.TrimSpace(string(v.Unhandled.Message)); msg != "" {
+			a.dartPending = &DartException{Type: "Exception", Message: Rest(msg)} // Type isn't printed
+		} else {
+			a.dartAwait = true
+		}
+		return out, true
+	}
+	return out, false
+}
+
+// feedTerraform handles a parsed line of Terraform output.
+func (a *Assembler) feedTerraform(v *TerraformParseResult, line string) []ErrorInfo {
+	if v.Location != nil && a.tfPending != nil && a.tfPending.Filename == "" {
+		loc := ErrorInfo{Filename: v.Location.Filename, Line: v.Location.Line}
+		if ctx := strings.TrimSuffix(strings.TrimSpace(string(v.Location.Context)), ":"); ctx != "" {
+			loc.Notes = []string{ctx}
+		}
+		*a.tfPending = a.tfPending.MergedWith(loc)
+		a.tfParagraph = true
+		a.addRaw(line)
+		return nil
+	}
+	if v.BoxEdge == "╷" && a.tfPending == nil {
+		return nil // Box opening before the summary line
+	}
+	out := a.takePending()
+	if v.Diagnostic != nil {
+		info := v.Diagnostic.ToErrorInfo()
+		a.tfPending, a.tfParagraph = &info, true
+		a.startRaw(line)
+	}
+	return out
+}
+
+// addTerraformDetail adds a detail line of the pending Terraform diagnostic to its notes.
+// Blank lines separate paragraphs; the lines of a wrapped paragraph are joined.
+func (a *Assembler) addTerraformDetail(text string) {
+	switch {
+	case text == "":
+		a.tfParagraph = true
+	case a.tfParagraph || len(a.tfPending.Notes) == 0:
+		a.tfPending.Notes = append(a.tfPending.Notes, text)
+		a.tfParagraph = false
+	default:
+		a.tfPending.Notes[len(a.tfPending.Notes)-1] += " " + text
+	}
+}
+
+// feedElixir handles a parsed line of Elixir output and reports whether it matched.
+// A location line only matches right after a warning, since on its own it is
+// indistinguishable from other languages' `file:line:` output.
+func (a *Assembler) feedElixir(v *ElixirParseResult, line string) ([]ErrorInfo, bool) {
+	switch {
+	case v.Gutter != nil:
+		if a.elixirWarn != nil {
+			a.addRaw(line)
+		}
+		return nil, true // Source excerpt between a warning and its location
+	case v.Location != nil && a.elixirWarn != nil:
+		var loc ErrorInfo
+		v.Location.Location.applyTo(&loc)
+		info := a.elixirWarn.MergedWith(loc)
+		info.Raw = strings.Join(append(a.raw, line), "\n")
+		a.elixirWarn, a.raw = nil, nil
+		return []ErrorInfo{info}, true
+	case v.Location != nil:
+		return a.takePending(), false
+	}
+	out := a.takePending()
+	switch {
+	case v.Error != nil:
+		out = append(out, withRaw(v.Error.ToErrorInfo(), line))
+	case v.Warning != nil:
+		info := v.Warning.ToErrorInfo()
+		a.elixirWarn = &info
+		a.startRaw(line)
+	}
+	return out, true
+}
+
+// feedJava handles a parsed line of Java output.
+func (a *Assembler) feedJava(v *JavaParseResult, line string) []ErrorInfo {
+	if a.javaPending != nil && (v.Frame != nil || v.Cause != nil || v.More > 0) {
+		a.addRaw(line)
+		switch {
+		case v.Frame != nil && a.javaCause != nil:
+			a.javaCause.Frames = append(a.javaCause.Frames, *v.Frame)
+			return nil
+		case v.Frame != nil:
+			a.javaPending.Frames = append(a.javaPending.Frames, *v.Frame)
+			return nil
+		case v.Cause != nil:
+			a.javaPending.Causes = append(a.javaPending.Causes, *v.Cause)
+			a.javaCause = &a.javaPending.Causes[len(a.javaPending.Causes)-1]
+			return nil
+		case v.More > 0:
+			return nil
+		}
+	}
+	out := a.takePending()
+	if v.Exception != nil {
+		a.javaPending = v.Exception
+		a.startRaw(line)
+	}
+	return out
+}
+
+// Flush returns any error still waiting for continuation lines and resets the context.
+// Call it once the input is exhausted. Context cut off by the end of a clipped log is
+// returned as a partial error rather than dropped: Python frames without their error
+// line give a location-only error, and a Rust message without its location a message-only one.
+func (a *Assembler) Flush() []ErrorInfo {
+	var out []ErrorInfo
+	for _, pkg := range a.goJSONOrder {
+		out = append(out, withPackage(a.goJSON[pkg].Flush(), pkg)...)
+	}
+	a.goJSON, a.goJSONOrder = nil, nil
+	for _, pid := range a.logcatOrder {
+		out = append(out, a.logcat[pid].Flush()...)
+	}
+	a.logcat, a.logcatOrder = nil, nil
+	for _, sub := range a.all {
+		sub.lineNo = a.lineNo
+		out = append(out, withLang(sub.Flush(), sub.lang)...)
+	}
+	if len(a.pythonTrace.Frames) > 0 {
+		out = append(out, withRaw(a.pythonTrace.ToErrorInfo(), strings.Join(a.pythonRaw, "\n")))
+	}
+	a.pythonTrace, a.pythonRaw = PythonTraceback{}, nil
+	a.goTestsLogged = nil
+	return a.untrim(a.atLine(append(out, a.takePending()...)))
+}
+
+// feedAll feeds line to the per-language Assemblers of LangAll in detection order,
+// stopping at the first one that matches it. Languages tried before the winner
+// saw an unmatched line, which may complete their own pending errors, while later
+// ones never see the line, so every matched line belongs to exactly one language.
+func (a *Assembler) feedAll(line string) ([]ErrorInfo, bool) {
+	var out []ErrorInfo
+	for _, sub := range a.all {
+		done, matched := sub.feedAt(line, a.lineNo)
+		out = append(out, withLang(done, sub.lang)...)
+		if matched {
+			return out, true
+		}
+	}
+	return out, false
+}
+
+// feedGoJSON handles a line of `go test -json` or `go build -json` output. The Output
+// of each event is fed to a Go Assembler of its package, so the interleaved output of
+// packages built or tested in parallel doesn't mix, and errors are attributed to the
+// package. An assertion line is attributed to the Test of its event, and a failing test
+// is reported once: by its assertion lines, or if it printed none by its --- FAIL line.
+// A package's pending errors are flushed when its final event arrives.
+// Lines that are not JSON events, such as build errors printed around them, are parsed
+// as plain Go output.
+func (a *Assembler) feedGoJSON(line string) ([]ErrorInfo, bool) {
+	pkg, test, text := "", "", line
+	ev, err := parseGoJSONEvent(line)
+	if err == nil {
+		pkg, test, text = ev.pkg(), ev.Test, strings.TrimSuffix(ev.Output, "\n")
+	}
+	sub, ok := a.goJSON[pkg]
+	if !ok {
+		if a.goJSON == nil {
+			a.goJSON = map[string]*Assembler{}
+		}
+		sub = NewAssembler(LangGo)
+		sub.SetMaxFrames(a.maxFrames)
+		a.goJSON[pkg] = sub
+		a.goJSONOrder = append(a.goJSONOrder, pkg)
+	}
+	if err == nil && ev.Output == "" {
+		if ev.ends() {
+			sub.lineNo = a.lineNo
+			return withPackage(sub.Flush(), pkg), false
+		}
+		return nil, false
+	}
+	sub.goEventTest = test
+	out, matched := sub.feedAt(text, a.lineNo)
+	return withPackage(out, pkg), matched
+}
+
+// feedLogcat handles a line of adb logcat output. The message after the logcat prefix
+// is fed to a Java Assembler of its process, so the stack traces of processes logging
+// at the same time don't mix. A crash is located at the first frame in the app's code
+// rather than in the Android platform, see JavaException.appFrame, and gets the thread
+// of the "FATAL EXCEPTION:" line before it. Lines without a logcat prefix are parsed as
+// plain Java output.
+func (a *Assembler) feedLogcat(line string) ([]ErrorInfo, bool) {
+	pid, text := 0, line
+	entry, err := parseLogcatLine(line)
+	if err == nil {
+		pid, text = entry.PID, entry.Message
+	}
+	sub, ok := a.logcat[pid]
+	if !ok {
+		if a.logcat == nil {
+			a.logcat = map[int]*Assembler{}
+		}
+		sub = NewAssembler(LangJava)
+		sub.android = true
+		a.logcat[pid] = sub
+		a.logcatOrder = append(a.logcatOrder, pid)
+	}
+	out, matched := sub.feedAt(text, a.lineNo)
+	if err != nil {
+		return out, matched
+	}
+	if thread, ok := entry.fatalThread(); ok {
+		sub.androidThread = thread
+		return out, true
+	}
+	if m := logcatProcess.FindStringSubmatch(strings.TrimSpace(text)); m != nil {
+		sub.androidApp = m[1]
+		return out, true
+	}
+	return out, matched
+}
+
+// withPackage records pkg as the Package of infos that have none.
+func withPackage(infos []ErrorInfo, pkg string) []ErrorInfo {
+	for i := range infos {
+		if infos[i].Package == "" {
+			infos[i].Package = pkg
+		}
+	}
+	return infos
+}
+
+// withLang records lang as the DetectedLang of infos.
+func withLang(infos []ErrorInfo, lang Language) []ErrorInfo {
+	for i := range infos {
+		infos[i].DetectedLang = lang.String()
+	}
+	return infos
+}
+
+// takePending returns and clears errors whose continuation lines have ended:
+// a Rust diagnostic whose excerpt and notes ended, a Rust panic whose message and backtrace ended, an Elixir warning without a location, a failing Go test without assertion lines,
+// a Go compile error, GHC or OCaml diagnostic whose continuation lines ended, a Lua or Ruby error whose backtrace ended,
+// or a Go panic, Java exception, Node.js error or Dart exception whose stack trace is complete.
+// At most one of them is pending at a time, so they share the raw input lines.
+func (a *Assembler) takePending() []ErrorInfo {
+	raw, rawLine := strings.Join(a.raw, "\n"), a.rawLine
+	if len(a.raw) == 0 {
+		rawLine = 0 // Left to atLine
+	}
+	a.raw = nil
+	var out []ErrorInfo
+	if a.javaPending != nil {
+		info := withRaw(a.javaPending.ToErrorInfo(), raw)
+		if a.android {
+			if frame, ok := a.javaPending.appFrame(a.androidApp); ok {
+				info.Filename, info.Line = frame.File, frame.Line
+			}
+			info.Thread, a.androidThread = a.androidThread, ""
+		}
+		out = append(out, info)
+		a.javaPending = nil
+		a.javaCause = nil
+	}
+	if a.goPending != nil {
+		out = append(out, withRaw(*a.goPending, raw))
+		a.goPending = nil
+	}
+	if a.nodePending != nil {
+		out = append(out, withRaw(a.nodePending.ToErrorInfo(), raw))
+		a.nodePending = nil
+	}
+	if a.goPanic != nil {
+		out = append(out, withRaw(a.goPanic.ToErrorInfo(), raw))
+		a.goPanic = nil
+		a.goInStack = false
+		a.goCall = ""
+	}
+	if a.goRace != nil {
+		out = append(out, withRaw(a.goRace.ToErrorInfo(), raw))
+		a.goRace = nil
+		a.goCall = ""
+	}
+	if a.rustPending != nil {
+		out = append(out, withRaw(*a.rustPending, raw))
+		a.rustPending = nil
+	}
+	if a.rustPanic != nil {
+		out = append(out, withRaw(a.rustPanic.ToErrorInfo(), raw))
+		a.rustPanic = nil
+	}
+	if a.elixirWarn != nil {
+		out = append(out, withRaw(*a.elixirWarn, raw))
+		a.elixirWarn = nil
+	}
+	if a.haskellErr != nil {
+		out = append(out, withRaw(a.haskellErr.ToErrorInfo(), raw))
+		a.haskellErr = nil
+	}
+	if a.dartPending != nil {
+		out = append(out, withRaw(a.dartPending.ToErrorInfo(), raw))
+		a.dartPending = nil
+	}
+	a.dartAwait = false
+	a.goLintLines = 0
+	if a.tfPending != nil {
+		out = append(out, withRaw(*a.tfPending, raw))
+		a.tfPending = nil
+	}
+	if a.cljPending != nil {
+		out = append(out, withRaw(a.cljPending.ToErrorInfo(), raw))
+		a.cljPending = nil
+	}
+	if a.wpPending != nil {
+		out = append(out, withRaw(*a.wpPending, raw))
+		a.wpPending = nil
+	}
+	if a.mlPending != nil {
+		out = append(out, withRaw(*a.mlPending, raw))
+		a.mlPending = nil
+	}
+	a.mlLoc = nil
+	if a.luaPending != nil {
+		out = append(out, withRaw(a.luaPending.ToErrorInfo(), raw))
+		a.luaPending = nil
+	}
+	if a.rubyPending != nil {
+		out = append(out, withRaw(a.rubyPending.ToErrorInfo(), raw))
+		a.rubyPending = nil
+	}
+	if a.goTest != nil {
+		if !a.goTestSeen {
+			out = append(out, withRaw(a.goTest.ToErrorInfo(), raw))
+		}
+		a.goTest = nil
+		a.goTestSeen = false
+	}
+	for i := range out {
+		out[i].InputLine = rawLine // The line that started the error
+	}
+	return out
+}
+
+// ParseLines parses a whole log and returns the fully reassembled errors in input order.
+// It keeps no global state, so concurrent calls with different languages are safe.
+// LangAuto detects the language from the lines first.
+func ParseLines(lines []string, lang Language) []ErrorInfo {
+	if lang == LangAuto {
+		lang = DetectLanguage(lines)
+	}
+	a := NewAssembler(lang)
+	var infos []ErrorInfo
+	for _, line := range lines {
+		done, _ := a.Feed(line)
+		infos = append(infos, done...)
+	}
+	return append(infos, a.Flush()...)
+}
+
+// DefaultMaxLineBytes is the longest line ParseStream accepts. Minified JavaScript
+// stack traces and large panic values easily exceed bufio.Scanner's 64KB default.
+const DefaultMaxLineBytes = 1 << 20
+
+// ParseStream reads r line by line and calls fn with each error as soon as it is complete,
+// doing the same reassembly as ParseLines without buffering the whole input.
+// Pending context is flushed when r reaches EOF. LangAuto detects the language from
+// the first DetectSampleSize non-empty lines, which are held back until then.
+// It returns the first read error, if any, including bufio.ErrTooLong for a line
+// longer than DefaultMaxLineBytes.
+func ParseStream(r io.Reader, lang Language, fn func(ErrorInfo)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, DefaultMaxLineBytes)
+
+	var sample []string
+	if lang == LangAuto {
+		nonEmpty := 0
+		for nonEmpty < DetectSampleSize && scanner.Scan() {
+			sample = append(sample, scanner.Text())
+			if scanner.Text() != "" {
+				nonEmpty++
+			}
+		}
+		lang = DetectLanguage(sample)
+	}
+
+	a := NewAssembler(lang)
+	feed := func(line string) {
+		done, _ := a.Feed(line)
+		for _, info := range done {
+			fn(info)
+		}
+	}
+	for _, line := range sample {
+		feed(line)
+	}
+	for scanner.Scan() {
+		feed(scanner.Text())
+	}
+	for _, info := range a.Flush() {
+		fn(info)
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Bazel Grammar ---
+// Example 1: ERROR: /home/dev/ws/src/BUILD:10:5: Compiling src/main.cc failed: (Exit 1): gcc failed
+// Example 2: WARNING: lib/BUILD.bazel:3:1: target '//lib:util' is deprecated
+// Example 3: ERROR: //src:main: missing input file '//src:data.txt'
+// Bazel locates most problems in a BUILD file; some are reported against a target label
+// instead, which has no file or line and is kept at the front of the message.
+// Progress and summary lines ("ERROR: Build did NOT complete successfully") are left unmatched.
+type BazelError struct {
+	Level   string `parser:"@('ERROR' | 'WARNING') ':'"`
+	Path    string `parser:"@Path ':'"` // BUILD file, or the package of a target label
+	Line    int    `parser:"( @Number"`
+	Column  *int   `parser:"  (':' @Number)?"`
+	Target  string `parser:"| @(Word | Path) ) ':'"` // Target name of a //pkg:name label
+	Message Rest   `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// normalize rejects labels that are not workspace-relative, so "ERROR: foo.cc:bar: ..."
+// isn't mistaken for a target.
+func (e *BazelError) normalize() error {
+	if e.Target != "" && !strings.HasPrefix(e.Path, "//") {
+		return fmt.Errorf("not a bazel target label: %s:%s", e.Path, e.Target)
+	}
+	return nil
+}
+
+// ToErrorInfo converts a parsed BazelError into the common ErrorInfo format.
+func (e *BazelError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Path,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     capitalize(e.Level), // "ERROR" -> "Error", "WARNING" -> "Warning"
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if e.Target != "" {
+		info.Filename = ""
+		info.Message = e.Path + ":" + e.Target + ": " + info.Message
+	}
+	return info
+}
+
+// Bazel parser instance
+var bazelParser = participle.MustBuild[BazelError](commonParserOptions...)
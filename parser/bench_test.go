@@ -0,0 +1,58 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Benchmarks ---
+// Both benchmarks report lines/s, so results for inputs of different lengths compare:
+//
+//	go test -run '^$' -bench . -benchmem ./parser
+
+// mixedLog concatenates the input of all fixtures: a log of every supported language,
+// with the blank lines, prose and unmatched noise of real build output.
+func mixedLog(tb testing.TB) []string {
+	var lines []string
+	for _, f := range readFixtures(tb) {
+		lines = append(lines, f.input...)
+	}
+	return lines
+}
+
+// BenchmarkParseLine parses the fixture lines written for each language with its grammar.
+func BenchmarkParseLine(b *testing.B) {
+	fixtures := readFixtures(b)
+	for _, lang := range builtinLanguages() {
+		if lang >= parser.LangAuto {
+			continue // Not a grammar, only ParseLines handles them
+		}
+		var lines []string
+		for _, f := range fixturesFor(fixtures, lang) {
+			lines = append(lines, f.input...)
+		}
+		b.Run(lang.String(), func(b *testing.B) {
+			for b.Loop() {
+				for _, line := range lines {
+					_, _ = parser.ParseLine(line, lang)
+				}
+			}
+			b.ReportMetric(float64(b.N*len(lines))/b.Elapsed().Seconds(), "lines/s")
+		})
+	}
+}
+
+// BenchmarkParseLines parses the mixed log as a few common languages, and with detection
+// and every grammar at once.
+func BenchmarkParseLines(b *testing.B) {
+	lines := mixedLog(b)
+	for _, lang := range []parser.Language{parser.LangGo, parser.LangPython, parser.LangRust, parser.LangTypeScript, parser.LangAuto, parser.LangAll} {
+		b.Run(lang.String(), func(b *testing.B) {
+			for b.Loop() {
+				parser.ParseLines(lines, lang)
+			}
+			b.ReportMetric(float64(b.N*len(lines))/b.Elapsed().Seconds(), "lines/s")
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Clojure Grammar ---
+// Clojure 1.10+ prints the phase and location on one line and the message on the next:
+// Example 1: Syntax error compiling at (src/app/core.clj:10:5).
+//
+//	Unable to resolve symbol: foo in this context
+//
+// Example 2: Syntax error (ClassCastException) compiling at (core.clj:3:1).
+// Example 3: Execution error (ArithmeticException) at app.core/divide (core.clj:5).
+// Older versions and Leiningen print the exception with the location at the end:
+// Example 4: CompilerException java.lang.RuntimeException: Unable to resolve symbol: foo in this context, compiling:(core.clj:10:5)
+// Example 5: Exception in thread "main" java.lang.RuntimeException: EOF while reading, compiling:(app/core.clj:42:1)
+
+// ClojureError is the header of a Clojure 1.10+ error, whose message follows on the next line.
+type ClojureError struct {
+	Phase string `parser:"@('Syntax' | 'Execution') 'error'"`
+	Class string `parser:"( LParen @(Word | Path) RParen )?"` // e.g. ClassCastException
+	Text  Rest   `parser:"@@"`                                // e.g. compiling at (core.clj:10:5).
+
+	Location ClojureLocation // Split off Text after parsing
+	Message  string          // Filled in by the Assembler from the following line
+
+	Pos lexer.Position
+}
+
+// ClojureCompilerException is a one-line exception ending in ", compiling:(file:line:col)".
+type ClojureCompilerException struct {
+	Thread  string `parser:"( 'Exception' 'in' 'thread' @String )?"`
+	Class   string `parser:"'CompilerException'? @Path"` // e.g. java.lang.RuntimeException
+	Message Rest   `parser:"':' @@"`
+
+	Location ClojureLocation // Split off Message after parsing
+
+	Pos lexer.Position
+}
+
+// ClojureLocation is the "(file:line[:col])" location Clojure prints at the end of a line.
+type ClojureLocation struct {
+	Filename string
+	Line     int
+	Column   *int
+}
+
+var (
+	// clojureLocation matches the location at the end of a 1.10+ header, e.g. "at (core.clj:10:5)."
+	clojureLocation = regexp.MustCompile(`\(([^()\s]+?):(\d+)(?::(\d+))?\)\.?$`)
+	// clojureCompiling matches the location suffix of older compiler exceptions.
+	clojureCompiling = regexp.MustCompile(`,\s*compiling:\(([^()\s]+?):(\d+)(?::(\d+))?\)$`)
+
+	errNoClojureLocation = errors.New("no Clojure (file:line:col) location")
+)
+
+// cutClojureLocation removes the location matched by pattern from the end of text.
+func cutClojureLocation(text string, pattern *regexp.Regexp) (string, ClojureLocation, error) {
+	text = strings.TrimSpace(text)
+	m := pattern.FindStringSubmatchIndex(text)
+	if m == nil {
+		return text, ClojureLocation{}, errNoClojureLocation
+	}
+	loc := ClojureLocation{Filename: text[m[2]:m[3]]}
+	loc.Line, _ = strconv.Atoi(text[m[4]:m[5]])
+	if m[6] >= 0 {
+		col, _ := strconv.Atoi(text[m[6]:m[7]])
+		loc.Column = &col
+	}
+	return strings.TrimSpace(text[:m[0]]), loc, nil
+}
+
+// applyTo sets the location fields of info.
+func (l ClojureLocation) applyTo(info *ErrorInfo) {
+	info.Filename, info.Line, info.Column = l.Filename, l.Line, l.Column
+}
+
+// ToErrorInfo converts a ClojureError into the common ErrorInfo format.
+// The exception class becomes the type; syntax errors without one are reported as SyntaxError.
+func (e *ClojureError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{Type: e.Class, Message: e.Message, ParsePos: parsePos(e.Pos)}
+	if info.Type == "" {
+		info.Type = e.Phase + "Error" // "SyntaxError", "ExecutionError"
+	}
+	e.Location.applyTo(&info)
+	return info
+}
+
+// ToErrorInfo converts a ClojureCompilerException into the common ErrorInfo format.
+func (e *ClojureCompilerException) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{Type: e.Class, Message: string(e.Message), ParsePos: parsePos(e.Pos)}
+	e.Location.applyTo(&info)
+	return info
+}
+
+// --- Clojure Specific Grammar ---
+// ClojureParseResult holds the result of parsing a single line of Clojure output.
+type ClojureParseResult struct {
+	Error     *ClojureError             `parser:"( @@ EOL?"`
+	Exception *ClojureCompilerException `parser:"| @@ EOL? )"`
+}
+
+// normalize splits the trailing location off the line; lines without one are rejected.
+func (r *ClojureParseResult) normalize() error {
+	var err error
+	if e := r.Error; e != nil {
+		var text string
+		text, e.Location, err = cutClojureLocation(string(e.Text), clojureLocation)
+		e.Text = Rest(text)
+	}
+	if e := r.Exception; e != nil {
+		var msg string
+		msg, e.Location, err = cutClojureLocation(string(e.Message), clojureCompiling)
+		e.Message = Rest(msg)
+	}
+	return err
+}
+
+// Clojure parser instance
+var clojureParser = participle.MustBuild[ClojureParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,126 @@
+package parser_test
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Concurrency ---
+// ParseLine and ParseLines are documented as safe for concurrent use, also while custom
+// languages are registered. These tests call them from many goroutines at once and are
+// meant to run with -race, as CI does.
+
+// builtinLanguages lists every built-in language, LangAuto and LangAll included.
+func builtinLanguages() []parser.Language {
+	var langs []parser.Language
+	for l := parser.LangUnknown + 1; l <= parser.LangAll; l++ {
+		langs = append(langs, l)
+	}
+	return langs
+}
+
+// fixturesFor returns the fixtures written for lang, so that each parser sees the input
+// it is meant for. The languages that try every parser get every third fixture, as they
+// are by far the slowest under the race detector.
+func fixturesFor(fixtures []fixture, lang parser.Language) []fixture {
+	var picked []fixture
+	for i, f := range fixtures {
+		if f.lang == lang || (lang == parser.LangAuto || lang == parser.LangAll) && i%3 == 0 {
+			picked = append(picked, f)
+		}
+	}
+	return picked
+}
+
+// lineResult is what ParseLine returned for one line, comparable across calls.
+type lineResult struct {
+	result any
+	err    string
+}
+
+// parseAll parses every fixture line and block as lang.
+func parseAll(fixtures []fixture, lang parser.Language) ([]lineResult, [][]parser.ErrorInfo) {
+	var lines []lineResult
+	var blocks [][]parser.ErrorInfo
+	for _, f := range fixtures {
+		for _, line := range f.input {
+			result, err := parser.ParseLine(line, lang)
+			r := lineResult{result: result}
+			if err != nil {
+				r.err = err.Error()
+			}
+			lines = append(lines, r)
+		}
+		blocks = append(blocks, parser.ParseLines(f.input, lang))
+	}
+	return lines, blocks
+}
+
+func TestConcurrentParsing(t *testing.T) {
+	fixtures := readFixtures(t)
+	langs := builtinLanguages()
+	workers := 4
+	if testing.Short() {
+		workers = 2
+	}
+
+	type results struct {
+		lines  []lineResult
+		blocks [][]parser.ErrorInfo
+	}
+	want := map[parser.Language]results{}
+	for _, lang := range langs {
+		lines, blocks := parseAll(fixturesFor(fixtures, lang), lang)
+		want[lang] = results{lines, blocks}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 20 {
+			name := fmt.Sprintf("concurrency-test-%d", i)
+			if _, err := parser.RegisterCustomLanguage(name, regexp.MustCompile(`^(?P<filename>\S+): (?P<message>.*)$`), nil); err != nil {
+				t.Errorf("registering %s: %v", name, err)
+			}
+		}
+	}()
+	for _, lang := range langs {
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				lines, blocks := parseAll(fixturesFor(fixtures, lang), lang)
+				if !reflect.DeepEqual(lines, want[lang].lines) {
+					t.Errorf("%s: ParseLine results differ when called concurrently", lang)
+				}
+				if !reflect.DeepEqual(blocks, want[lang].blocks) {
+					t.Errorf("%s: ParseLines results differ when called concurrently", lang)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+// BenchmarkParseLinesParallel parses the fixtures from GOMAXPROCS goroutines, each
+// iteration taking the next block and language.
+func BenchmarkParseLinesParallel(b *testing.B) {
+	fixtures := readFixtures(b)
+	langs := builtinLanguages()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			f := fixtures[i%len(fixtures)]
+			lang := langs[i%len(langs)]
+			for _, line := range f.input {
+				_, _ = parser.ParseLine(line, lang)
+			}
+			parser.ParseLines(f.input, lang)
+		}
+	})
+}
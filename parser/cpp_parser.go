@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- C/C++ Grammar (GCC/Clang) ---
+// Example 1: main.cpp:10:15: error: expected ';' after expression
+// Example 2: main.cpp:4:9: warning: unused variable 'x' [-Wunused-variable]
+// Example 3: main.cpp:3:6: note: declared here
+// Example 4: main.c:1:10: fatal error: foo.h: No such file or directory
+type CppDiagnostic struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   *int   `parser:"(':' @Number)?"` // Omitted with -fno-show-column
+	Severity string `parser:"':' ( @'fatal' 'error' | @('error' | 'warning' | 'note') )"`
+	Message  Rest   `parser:"':' @@"`
+
+	Flag string // Warning option such as -Wunused-variable, split off the message after parsing
+
+	Pos lexer.Position
+}
+
+// normalize moves a trailing "[-Wflag]" from the message into Flag.
+func (e *CppDiagnostic) normalize() error {
+	msg := strings.TrimSpace(string(e.Message))
+	start := strings.LastIndex(msg, " [-W")
+	if start < 0 || !strings.HasSuffix(msg, "]") {
+		return nil
+	}
+	e.Flag = msg[start+2 : len(msg)-1]
+	e.Message = Rest(msg[:start])
+	return nil
+}
+
+// ToErrorInfo converts a parsed CppDiagnostic into the common ErrorInfo format.
+func (e *CppDiagnostic) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     capitalize(e.Severity), // "error" -> "Error", "fatal" -> "Fatal"; notes become "Note" so callers can filter them
+		Code:     e.Flag,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// C/C++ parser instance
+var cppParser = participle.MustBuild[CppDiagnostic](commonParserOptions...)
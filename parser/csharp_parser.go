@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- C# Grammar (dotnet build / MSBuild) ---
+// Example 1: Program.cs(10,5): error CS0103: The name 'foo' does not exist in the current context
+// Example 2: C:\src\App\Program.cs(3,7): warning CS0168: The variable 'x' is declared but never used [C:\src\App\App.csproj]
+// Example 3: Models/User.cs(12,9,12,20): error CS1061: 'User' does not contain a definition for 'Nme'
+// The location is parenthesized, optionally with the end of the span, and MSBuild appends
+// the project the file belongs to in square brackets.
+type CSharpError struct {
+	Filename string        `parser:"@Path"`
+	Location ParenLocation `parser:"@@"`
+	Severity string        `parser:"':' @('error' | 'warning')"`
+	Code     string        `parser:"@Word"` // e.g. CS0103, but also analyzer codes like CA1822 or IDE0051
+	Message  Rest          `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// normalize drops the trailing " [project.csproj]" MSBuild adds to every diagnostic.
+func (e *CSharpError) normalize() error {
+	e.Message = Rest(stripProject(string(e.Message)))
+	return nil
+}
+
+// ToErrorInfo converts a parsed CSharpError into the common ErrorInfo format.
+func (e *CSharpError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos:  parsePos(e.Pos),
+		Filename:  e.Filename,
+		Line:      e.Location.Line,
+		Column:    e.Location.Column,
+		EndLine:   e.Location.EndLine,
+		EndColumn: e.Location.EndColumn,
+		Type:      capitalize(e.Severity), // Capitalize "error" -> "Error", "warning" -> "Warning"
+		Code:      e.Code,
+		Message:   string(e.Message),
+	}
+}
+
+// C# parser instance
+var csharpParser = participle.MustBuild[CSharpError](commonParserOptions...)
@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Custom Languages ---
+// Build tools with their own error format can be supported without writing a grammar:
+// RegisterCustomLanguage takes a regular expression whose named capture groups are
+// copied into ErrorInfo, e.g.
+//
+//	^(?P<filename>[^:]+):(?P<line>\d+): (?P<type>ERROR|WARN) (?P<message>.*)$
+
+// FieldMap maps ErrorInfo fields (filename, line, column, type, code, message) to the
+// names of the capture groups holding them. Fields missing from the map are read from
+// the group of the same name, so a nil FieldMap uses the field names as group names.
+type FieldMap map[string]string
+
+// customFields lists the ErrorInfo fields a custom pattern can fill.
+var customFields = []string{"filename", "line", "column", "type", "code", "message"}
+
+// customRequired lists the fields every custom pattern must capture.
+var customRequired = []string{"filename", "message"}
+
+// customLanguage is a registered custom language.
+type customLanguage struct {
+	name    string
+	pattern *regexp.Regexp
+	groups  map[string]int // ErrorInfo field -> submatch index
+}
+
+var (
+	customMu        sync.RWMutex
+	customLanguages []*customLanguage // Language langCustomStart+i is customLanguages[i]
+)
+
+// RegisterCustomLanguage registers a language whose error lines are matched by pattern
+// and returns its Language value, usable with ParseLine, Assembler and ParseStream like
+// the built-in ones. Custom languages are not considered by DetectLanguage.
+// It returns an error if the name is taken or the pattern lacks a required group
+// (filename and message) or a group named in mapping.
+// It is safe for concurrent use.
+func RegisterCustomLanguage(name string, pattern *regexp.Regexp, mapping FieldMap) (Language, error) {
+	for field := range mapping {
+		if !slices.Contains(customFields, field) {
+			return LangUnknown, fmt.Errorf("unknown field %q in mapping, expected one of %s", field, strings.Join(customFields, ", "))
+		}
+	}
+
+	lang := &customLanguage{name: name, pattern: pattern, groups: map[string]int{}}
+	for _, field := range customFields {
+		group := field
+		if g, ok := mapping[field]; ok {
+			group = g
+		}
+		if i := pattern.SubexpIndex(group); i >= 0 {
+			lang.groups[field] = i
+		} else if _, ok := mapping[field]; ok {
+			return LangUnknown, fmt.Errorf("pattern has no capture group %q for field %s", group, field)
+		}
+	}
+	for _, field := range customRequired {
+		if _, ok := lang.groups[field]; !ok {
+			return LangUnknown, fmt.Errorf("pattern has no capture group for required field %s", field)
+		}
+	}
+
+	customMu.Lock()
+	defer customMu.Unlock()
+	if _, ok := LanguageByName(name); ok {
+		return LangUnknown, fmt.Errorf("language %q is built in", name)
+	}
+	for _, c := range customLanguages {
+		if c.name == name {
+			return LangUnknown, fmt.Errorf("language %q is already registered", name)
+		}
+	}
+	customLanguages = append(customLanguages, lang)
+	return langCustomStart + Language(len(customLanguages)-1), nil
+}
+
+// lookupCustom returns the custom language registered as lang, if any.
+func lookupCustom(lang Language) (*customLanguage, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	i := int(lang - langCustomStart)
+	if i < 0 || i >= len(customLanguages) {
+		return nil, false
+	}
+	return customLanguages[i], true
+}
+
+// errNoCustomMatch rejects lines the pattern of a custom language does not match.
+var errNoCustomMatch = fmt.Errorf("line does not match the custom pattern")
+
+// CustomError is a line matched by a custom language's pattern.
+type CustomError struct {
+	Fields map[string]string // Captured text by ErrorInfo field name
+
+	Pos lexer.Position // Where the pattern matched in the line
+}
+
+// parse matches line against the pattern.
+func (c *customLanguage) parse(line string) (*CustomError, error) {
+	m := c.pattern.FindStringSubmatchIndex(line)
+	if m == nil {
+		return nil, errNoCustomMatch
+	}
+	fields := map[string]string{}
+	for field, i := range c.groups {
+		if m[2*i] >= 0 {
+			fields[field] = line[m[2*i]:m[2*i+1]]
+		}
+	}
+	return &CustomError{Fields: fields, Pos: offsetPos(line, m[0])}, nil
+}
+
+// ToErrorInfo converts a CustomError into the common ErrorInfo format.
+// Lines and columns that aren't numbers are left unset. Common severity spellings such as
+// ERROR or warn are normalized to Error, Warning and Note; the type defaults to "Error".
+func (e *CustomError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		Filename: strings.TrimSpace(e.Fields["filename"]),
+		Type:     strings.TrimSpace(e.Fields["type"]),
+		Code:     strings.TrimSpace(e.Fields["code"]),
+		Message:  strings.TrimSpace(e.Fields["message"]),
+		ParsePos: parsePos(e.Pos),
+	}
+	switch strings.ToLower(info.Type) {
+	case "", "error", "err", "fatal":
+		info.Type = "Error"
+	case "warning", "warn":
+		info.Type = "Warning"
+	case "note", "info":
+		info.Type = "Note"
+	}
+	info.Line, _ = strconv.Atoi(e.Fields["line"])
+	if col, err := strconv.Atoi(e.Fields["column"]); err == nil {
+		info.Column = &col
+	}
+	return info
+}
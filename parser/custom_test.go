@@ -0,0 +1,65 @@
+package parser_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+func TestRegisterCustomLanguage(t *testing.T) {
+	lang, err := parser.RegisterCustomLanguage("register-test", regexp.MustCompile(`^(?P<path>\S+):(?P<line>\d+) (?P<message>.*)$`), parser.FieldMap{"filename": "path"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang.String() != "register-test" {
+		t.Errorf("got language %s, want register-test", lang)
+	}
+	result, err := parser.ParseLine("build.ninja:7 unknown target", lang)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := result.(*parser.CustomError)
+	if !ok {
+		t.Fatalf("line not matched, got %T", result)
+	}
+	if info := e.ToErrorInfo(); info.Filename != "build.ninja" || info.Line != 7 || info.Type != "Error" || info.Message != "unknown target" {
+		t.Errorf("got %+v", info)
+	}
+
+	if _, err := parser.RegisterCustomLanguage("register-test", regexp.MustCompile(`(?P<filename>\S+) (?P<message>.*)`), nil); err == nil || err.Error() != `language "register-test" is already registered` {
+		t.Errorf("registering a name twice: got error %v", err)
+	}
+}
+
+func TestRegisterCustomLanguageErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		lang    string
+		pattern string
+		mapping parser.FieldMap
+		want    string
+	}{
+		{"missing filename group", "missing-filename", `^(?P<file>\S+): (?P<message>.*)$`, nil, "pattern has no capture group for required field filename"},
+		{"missing message group", "missing-message", `^(?P<filename>\S+): (?P<text>.*)$`, nil, "pattern has no capture group for required field message"},
+		{"unnamed groups only", "unnamed-groups", `^(\S+): (.*)$`, nil, "pattern has no capture group for required field filename"},
+		{"mapped group missing", "mapped-missing", `^(?P<filename>\S+): (?P<message>.*)$`, parser.FieldMap{"line": "lineno"}, `pattern has no capture group "lineno" for field line`},
+		{"unknown field", "unknown-field", `^(?P<filename>\S+): (?P<message>.*)$`, parser.FieldMap{"severity": "filename"}, `unknown field "severity" in mapping, expected one of filename, line, column, type, code, message`},
+		{"built-in name", "rust", `^(?P<filename>\S+): (?P<message>.*)$`, nil, `language "rust" is built in`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, err := parser.RegisterCustomLanguage(tt.lang, regexp.MustCompile(tt.pattern), tt.mapping)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("got %s, error %v, want error %s", lang, err, tt.want)
+			}
+			if tt.lang == "rust" {
+				return
+			}
+			// A failed registration leaves the name free
+			if _, err := parser.RegisterCustomLanguage(tt.lang, regexp.MustCompile(`^(?P<filename>\S+): (?P<message>.*)$`), nil); err != nil {
+				t.Errorf("registering %s after the error: %v", tt.lang, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package parser
+
+// --- Deduplication ---
+
+// CountedError is an ErrorInfo together with the number of times it was reported.
+type CountedError struct {
+	ErrorInfo
+	Count int `json:"count"`
+}
+
+// dedupKey identifies duplicate errors. The column is stored by value, -1 if absent.
+type dedupKey struct {
+	filename string
+	line     int
+	column   int
+	typ      string
+	message  string
+}
+
+// Dedup collapses errors with the same filename, line, column, type and message,
+// wherever they occur in infos. The first occurrence of each error is kept, in input
+// order, along with how often it was seen.
+func Dedup(infos []ErrorInfo) []CountedError {
+	var out []CountedError
+	index := map[dedupKey]int{}
+	for _, info := range infos {
+		key := dedupKey{filename: info.Filename, line: info.Line, column: -1, typ: info.Type, message: info.Message}
+		if info.Column != nil {
+			key.column = *info.Column
+		}
+		if i, ok := index[key]; ok {
+			out[i].Count++
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, CountedError{ErrorInfo: info, Count: 1})
+	}
+	return out
+}
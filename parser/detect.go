@@ -0,0 +1,57 @@
+package parser
+
+// --- Language Detection ---
+
+// DetectSampleSize is the number of non-empty lines DetectLanguage looks at.
+const DetectSampleSize = 50
+
+// detectableLanguages lists the concrete languages tried by DetectLanguage.
+// Earlier entries win when scores are fully tied, so stricter grammars come first
+// (e.g. a Flutter error line is also a valid Go compile error, but not vice versa,
+// and an MSVC diagnostic is also a valid C# one). LangGoJSON and LangLogcat come last
+// because they also parse plain Go and Java output, so they must lose ties with LangGo
+// and LangJava.
+var detectableLanguages = []Language{LangSwift, LangKotlin, LangFlutter, LangPHP, LangTerraform, LangMaven, LangBazel, LangMSVC, LangCSharp, LangScala, LangClojure, LangHaskell, LangOCaml, LangLua, LangCpp, LangTypeScript, LangWebpack, LangElixir, LangRust, LangGo, LangPython, LangNode, LangJava, LangRuby, LangPerl, LangGoJSON, LangLogcat}
+
+// DetectLanguage guesses the language of a log from its first DetectSampleSize non-empty lines.
+// Every language's parser is run over the sample and the one matching the most lines wins.
+// Ties prefer the language whose matches carry file/line locations over bare messages.
+// It returns LangUnknown if no language matches any line.
+func DetectLanguage(lines []string) Language {
+	var sample []string
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		sample = append(sample, line)
+		if len(sample) == DetectSampleSize {
+			break
+		}
+	}
+
+	best, bestMatched, bestLocated := LangUnknown, 0, 0
+	for _, lang := range detectableLanguages {
+		a := NewAssembler(lang)
+		matched, located := 0, 0
+		count := func(infos []ErrorInfo) {
+			for _, info := range infos {
+				if info.Filename != "" {
+					located++
+				}
+			}
+		}
+		for _, line := range sample {
+			infos, ok := a.Feed(line)
+			if ok {
+				matched++
+			}
+			count(infos)
+		}
+		count(a.Flush())
+
+		if matched > bestMatched || (matched == bestMatched && located > bestLocated) {
+			best, bestMatched, bestLocated = lang, matched, located
+		}
+	}
+	return best
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- Documentation Links ---
+
+// docURLRule links the codes matching pattern to their documentation. The URL is
+// template with $0, $1, ... replaced by the submatches, as in regexp.Expand.
+type docURLRule struct {
+	pattern  *regexp.Regexp
+	template string
+	lower    bool // Match and expand the lowercased code, for sites with lowercase anchors
+}
+
+// docURLRules lists the documented code families of each language. TypeScript has no
+// official page per error code, so its codes link to the typescript.tv error index.
+var docURLRules = map[Language][]docURLRule{
+	LangRust: {
+		{pattern: regexp.MustCompile(`^E\d{4}$`), template: "https://doc.rust-lang.org/error_codes/$0.html"},
+	},
+	LangTypeScript: {
+		{pattern: regexp.MustCompile(`^ts\d+$`), template: "https://typescript.tv/errors/#$0", lower: true},
+	},
+	LangCSharp: {
+		{pattern: regexp.MustCompile(`^cs\d{4}$`), template: "https://learn.microsoft.com/dotnet/csharp/misc/$0", lower: true},
+		{pattern: regexp.MustCompile(`^ca\d{4}$`), template: "https://learn.microsoft.com/dotnet/fundamentals/code-analysis/quality-rules/$0", lower: true},
+	},
+	LangCpp: {
+		{pattern: regexp.MustCompile(`^-(w[\w\-=+]+)$`), template: "https://clang.llvm.org/docs/DiagnosticsReference.html#$1", lower: true},
+	},
+	LangHaskell: {
+		{pattern: regexp.MustCompile(`^GHC-\d{5}$`), template: "https://errors.haskell.org/messages/$0/"},
+		{pattern: regexp.MustCompile(`^-W[\w\-]+$`), template: "https://downloads.haskell.org/ghc/latest/docs/users_guide/using-warnings.html#ghc-flag-$0"},
+	},
+	LangGo: {
+		{pattern: regexp.MustCompile(`^(?:SA|S|ST|QF|U)\d{4}$`), template: "https://staticcheck.dev/docs/checks/#$0"},
+		{pattern: regexp.MustCompile(`^[a-z][a-z0-9]*(?:[-_][a-z0-9]+)*$`), template: "https://golangci-lint.run/usage/linters/#$0"},
+	},
+}
+
+// DocURL returns the documentation URL for a diagnostic code reported by lang, such
+// as E0308 for Rust, TS2322 for TypeScript or SA1019 for Go. It reports false for
+// unknown codes and for languages without documented codes.
+func DocURL(lang Language, code string) (string, bool) {
+	if lang == LangGoJSON {
+		lang = LangGo
+	}
+	for _, rule := range docURLRules[lang] {
+		text := code
+		if rule.lower {
+			text = strings.ToLower(code)
+		}
+		if m := rule.pattern.FindStringSubmatchIndex(text); m != nil {
+			return string(rule.pattern.ExpandString(nil, rule.template, text, m)), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Elixir Grammar (elixirc / mix) ---
+// Example 1: ** (CompileError) lib/foo.ex:10: undefined function bar/0
+// Example 2: ** (Mix) Could not compile dependency :telemetry
+// Warnings print their location on a following line, either indented (Elixir < 1.15):
+//
+//	warning: variable "x" is unused
+//	  lib/foo.ex:10: Foo.bar/0
+//
+// or after a source excerpt drawn with box characters (Elixir >= 1.15):
+//
+//	    warning: variable "x" is unused
+//	    │
+//	 10 │   x = 1
+//	    │   ~
+//	    │
+//	    └─ lib/foo.ex:10:3: Foo.bar/0
+//
+// The Assembler joins the warning with its location.
+
+// ElixirError is an exception raised by the compiler or mix, anchored by the leading "** (".
+type ElixirError struct {
+	Exception string          `parser:"'*' '*' LParen @(Word | Path) RParen"` // e.g. CompileError, Protocol.UndefinedError
+	Location  *ElixirLocation `parser:"( @@ ':' )?"`
+	Message   Rest            `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// ElixirLocation is a file:line[:col] reference; the column is only printed by newer versions.
+type ElixirLocation struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   *int   `parser:"(':' @Number)?"`
+}
+
+// ElixirWarning is the first line of a warning; its location follows on a later line.
+type ElixirWarning struct {
+	Message Rest `parser:"'warning' ':' @@"`
+
+	Pos lexer.Position
+}
+
+// ElixirLocationLine is the line of a warning naming its location and the enclosing function.
+type ElixirLocationLine struct {
+	Location ElixirLocation `parser:"( '└' '─' )? @@"`
+	Function Rest           `parser:"( ':' @@ )?"` // e.g. Foo.bar/0
+
+	Pos lexer.Position
+}
+
+// ElixirGutter is a source excerpt line of a warning, e.g. " 10 │   x = 1".
+type ElixirGutter struct {
+	Text Rest `parser:"Number? '│' @@"`
+}
+
+// ToErrorInfo converts a parsed ElixirError into the common ErrorInfo format.
+func (e *ElixirError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     e.Exception,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if e.Location != nil {
+		e.Location.applyTo(&info)
+	}
+	return info
+}
+
+// ToErrorInfo converts a parsed ElixirWarning into the common ErrorInfo format.
+// The location is applied by the Assembler once its line is seen.
+func (e *ElixirWarning) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     "Warning",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// applyTo copies the location into info.
+func (l *ElixirLocation) applyTo(info *ErrorInfo) {
+	info.Filename = l.Filename
+	info.Line = l.Line
+	info.Column = l.Column
+}
+
+// --- Elixir Specific Grammar ---
+// ElixirParseResult holds the result of parsing a single line of Elixir output.
+type ElixirParseResult struct {
+	Error    *ElixirError        `parser:"( @@ EOL?"`
+	Warning  *ElixirWarning      `parser:"| @@ EOL?"`
+	Location *ElixirLocationLine `parser:"| @@ EOL?"`
+	Gutter   *ElixirGutter       `parser:"| @@ EOL? )"`
+}
+
+// Elixir parser instance
+var elixirParser = participle.MustBuild[ElixirParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// --- Filtering ---
+
+// Filter selects errors by regular expressions matched against their message and
+// filename, e.g. to drop known deprecation warnings from vendored code.
+// The zero Filter keeps every error.
+type Filter struct {
+	Ignore []*regexp.Regexp // Errors matching any of these are dropped
+	Only   []*regexp.Regexp // If not empty, only errors matching one of these are kept
+}
+
+// NewFilter compiles the ignore and only patterns into a Filter.
+// It returns an error naming the first pattern that is not a valid regular expression.
+func NewFilter(ignore, only []string) (*Filter, error) {
+	f := &Filter{}
+	for _, pattern := range ignore {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+		f.Ignore = append(f.Ignore, re)
+	}
+	for _, pattern := range only {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid only pattern %q: %w", pattern, err)
+		}
+		f.Only = append(f.Only, re)
+	}
+	return f, nil
+}
+
+// Keep reports whether info passes the filter: it matches none of the Ignore
+// patterns and, if there are Only patterns, at least one of them.
+func (f *Filter) Keep(info ErrorInfo) bool {
+	for _, re := range f.Ignore {
+		if matchesError(re, info) {
+			return false
+		}
+	}
+	if len(f.Only) == 0 {
+		return true
+	}
+	for _, re := range f.Only {
+		if matchesError(re, info) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesError reports whether re matches the message or the filename of info.
+func matchesError(re *regexp.Regexp, info ErrorInfo) bool {
+	return re.MatchString(info.Message) || re.MatchString(info.Filename)
+}
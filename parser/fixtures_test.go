@@ -0,0 +1,189 @@
+package parser_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Fixtures ---
+// The test/*.md files hold sample logs in fenced blocks. A block is parsed as the
+// language named after its opening fence, or else as the one the file is named after:
+//
+//	```rust
+//	warning: unused variable: `x`
+//	```
+//	```expect
+//	warning src/main.rs:2:9 Warning "unused variable: `x`"
+//	```
+//
+// An expect block right after an input block lists the errors it must produce, in the
+// form of formatFixtureError; an empty one expects none. After the language, the option
+// "loose" applies LooseMatch to unmatched lines like the -loose flag does. With
+// "expect frames", each error is followed by its stack frames, outermost call first, and
+// with "expect notes" by its notes, one indented line each:
+//
+//	error ./main.go:12:7 Error "not enough arguments in call to greet"
+//	  note "have (string)"
+//
+//	error Foo.java:42 java.lang.NullPointerException ""
+//	  frame Foo.java:18 com.example.Foo.bar
+//	  frame Foo.java:42 com.example.Foo$Inner.<init>
+
+// fixture is an input block of a test/*.md file.
+type fixture struct {
+	name   string          // File and line of the opening fence, e.g. test/go.md:12
+	lang   parser.Language // LangUnknown if neither the fence nor the file names one
+	loose  bool
+	input  []string
+	expect []string // nil without an expect block
+	frames bool     // Whether the expect block lists stack frames
+	notes  bool     // Whether the expect block lists notes
+}
+
+// readFixtures reads the blocks of all test/*.md files.
+func readFixtures(tb testing.TB) []fixture {
+	tb.Helper()
+	paths, err := filepath.Glob(filepath.Join("..", "test", "*.md"))
+	if err != nil || len(paths) == 0 {
+		tb.Fatalf("no fixtures found: %v", err)
+	}
+	var fixtures []fixture
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		fixtures = append(fixtures, parseFixtures(tb, path, string(data))...)
+	}
+	return fixtures
+}
+
+// parseFixtures splits the markdown of one fixture file into its blocks.
+func parseFixtures(tb testing.TB, path, data string) []fixture {
+	tb.Helper()
+	name := filepath.ToSlash(filepath.Join("test", filepath.Base(path)))
+	fileLang, _ := parser.LanguageByName(strings.TrimSuffix(filepath.Base(path), ".md"))
+
+	var fixtures []fixture
+	open := ""     // "input" or "expect" inside a block
+	closedAt := -1 // Line index of the fence that closed the last input block
+	for i, line := range strings.Split(strings.TrimSuffix(data, "\n"), "\n") {
+		info, fence := strings.CutPrefix(line, "```")
+		last := len(fixtures) - 1
+		switch {
+		case !fence && open == "input":
+			fixtures[last].input = append(fixtures[last].input, line)
+		case !fence && open == "expect":
+			fixtures[last].expect = append(fixtures[last].expect, line)
+		case !fence:
+			// Prose between blocks
+		case open != "":
+			if open == "input" {
+				closedAt = i
+			}
+			open = ""
+		case strings.HasPrefix(info, "expect"):
+			if closedAt != i-1 || fixtures[last].expect != nil {
+				tb.Fatalf("%s:%d: an expect block must directly follow its input block", name, i+1)
+			}
+			if fixtures[last].lang == parser.LangUnknown {
+				tb.Fatalf("%s: no language to check the expect block with, name one after the fence", fixtures[last].name)
+			}
+			for _, word := range strings.Fields(info)[1:] {
+				switch word {
+				case "frames":
+					fixtures[last].frames = true
+				case "notes":
+					fixtures[last].notes = true
+				default:
+					tb.Fatalf("%s:%d: unknown expect option %q", name, i+1, word)
+				}
+			}
+			fixtures[last].expect = []string{}
+			open = "expect"
+		default:
+			f := fixture{name: name + ":" + strconv.Itoa(i+1), lang: fileLang}
+			for _, word := range strings.Fields(info) {
+				lang, ok := parser.LanguageByName(word)
+				switch {
+				case ok:
+					f.lang = lang
+				case word == "loose":
+					f.loose = true
+				default:
+					tb.Fatalf("%s:%d: unknown language or option %q", name, i+1, word)
+				}
+			}
+			fixtures = append(fixtures, f)
+			open = "input"
+		}
+	}
+	return fixtures
+}
+
+// run parses the block the way the command does, returning the errors in order.
+func (f fixture) run() []parser.ErrorInfo {
+	a := parser.NewAssembler(f.lang)
+	var out []parser.ErrorInfo
+	for _, line := range f.input {
+		infos, matched := a.Feed(line)
+		out = append(out, infos...)
+		if !matched && f.loose && line != "" {
+			if info, ok := parser.LooseMatch(line); ok {
+				out = append(out, info)
+			}
+		}
+	}
+	return append(out, a.Flush()...)
+}
+
+// formatFixtureError renders an error as a line of an expect block: its severity, which
+// decides the exit code, the location (- if it has none), type and quoted message.
+//
+//	error calc_test.go:42 TestFailure "TestAdd: expected 3, got 4"
+func formatFixtureError(info parser.ErrorInfo) string {
+	loc := info.Filename
+	if info.Line > 0 {
+		loc += ":" + strconv.Itoa(info.Line)
+		if info.Column != nil {
+			loc += ":" + strconv.Itoa(*info.Column)
+		}
+	}
+	if loc == "" {
+		loc = "-"
+	}
+	return strings.Join([]string{info.Severity().String(), loc, info.Type, strconv.Quote(info.Message)}, " ")
+}
+
+func TestFixtures(t *testing.T) {
+	for _, f := range readFixtures(t) {
+		if f.expect == nil {
+			continue
+		}
+		t.Run(f.name, func(t *testing.T) {
+			var got []string
+			for _, info := range f.run() {
+				got = append(got, formatFixtureError(info))
+				if f.frames {
+					for _, frame := range info.Frames {
+						got = append(got, strings.TrimRight("  frame "+frame.Filename+":"+strconv.Itoa(frame.Line)+" "+frame.Function, " "))
+					}
+				}
+				if f.notes {
+					for _, note := range info.Notes {
+						got = append(got, "  note "+strconv.Quote(note))
+					}
+				}
+			}
+			if !slices.Equal(got, f.expect) {
+				t.Errorf("%s as %s:\ngot:\n%s\nwant:\n%s", f.name, f.lang, strings.Join(got, "\n"), strings.Join(f.expect, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Flutter Grammar ---
+// Example: lib/main.dart:9:1: Error: Type 'oid' not found.
+// Example: lib/main.dart:9: Warning: Operand of null-aware operation '?.' has type 'String'.
+type FlutterError struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   *int   `parser:"(':' @Number)?"` // Omitted by some tools
+	ErrType  string `parser:"':' @Word"`      // "Error", "Warning"
+	Message  Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+func (e *FlutterError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     e.ErrType,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// --- Dart Runtime Exceptions ---
+// Example:
+//
+//	Unhandled exception:
+//	FormatException: Invalid radix-10 number (at character 1)
+//	#0      int._parse (dart:core-patch/integers_patch.dart:103:12)
+//	#1      main (file:///home/me/app/bin/app.dart:4:7)
+//
+// Flutter apps print the message on the header line instead:
+//
+//	[ERROR:flutter/runtime/dart_vm_initializer.cc(41)] Unhandled Exception: Null check operator used on a null value
+//	#0      HomePage.build (package:my_app/home_page.dart:25:30)
+//
+// The Assembler attaches the exception line and the `#N` frames to the header.
+
+// DartUnhandled is the "Unhandled exception:" header of a Dart runtime exception.
+type DartUnhandled struct {
+	Message Rest `parser:"( LBracket (~RBracket)* RBracket )? 'Unhandled' ('exception' | 'Exception') ':' @@"`
+
+	Pos lexer.Position
+}
+
+// DartException is the exception type and message of an uncaught Dart exception.
+type DartException struct {
+	Type    string `parser:"@Word"` // e.g. FormatException, StateError
+	Message Rest   `parser:"':' @@"`
+
+	Frames []DartFrame // Filled in by the Assembler from the following `#N` lines
+
+	Pos lexer.Position
+}
+
+// DartFrame is one `#N` line of a Dart stack trace. Function names may contain spaces
+// ("<anonymous closure>"), so the text is split after parsing.
+type DartFrame struct {
+	Index int  `parser:"'#' @Number"`
+	Text  Rest `parser:"@@"`
+
+	Function string
+	File     string // Path for file:// URIs, otherwise the URI as printed (dart:core/..., package:app/...)
+	Line     int
+	Column   *int
+
+	Pos lexer.Position
+}
+
+// dartFrameText matches "func (uri:line[:col])".
+var dartFrameText = regexp.MustCompile(`^(.*?)\s+\((.+?):(\d+)(?::(\d+))?\)$`)
+
+// split separates the function from the location and converts file:// URIs into paths.
+func (f *DartFrame) split() {
+	text := strings.TrimSpace(string(f.Text))
+	m := dartFrameText.FindStringSubmatch(text)
+	if m == nil {
+		f.Function = text
+		return
+	}
+	f.Function = m[1]
+	f.File = filePath(m[2])
+	f.Line, _ = strconv.Atoi(m[3])
+	if m[4] != "" {
+		col, _ := strconv.Atoi(m[4])
+		f.Column = &col
+	}
+}
+
+// isSDK reports whether the frame belongs to the Dart SDK or the Flutter framework.
+func (f DartFrame) isSDK() bool {
+	return strings.HasPrefix(f.File, "dart:") || strings.HasPrefix(f.File, "package:flutter/")
+}
+
+// ToErrorInfo converts a DartException and its frames into the common ErrorInfo format.
+// The first application frame, skipping the SDK and framework, becomes the error location.
+func (e *DartException) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     e.Type,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	for _, frame := range e.Frames {
+		if frame.File == "" {
+			continue
+		}
+		info.Frames = append(info.Frames, Frame{Filename: frame.File, Line: frame.Line, Function: frame.Function})
+		if info.Filename == "" && !frame.isSDK() {
+			info.Filename, info.Line, info.Column = frame.File, frame.Line, frame.Column
+		}
+	}
+	// Dart prints the innermost call first; Frames lists the outermost call first
+	for i, j := 0, len(info.Frames)-1; i < j; i, j = i+1, j-1 {
+		info.Frames[i], info.Frames[j] = info.Frames[j], info.Frames[i]
+	}
+	return info
+}
+
+// --- Flutter Specific Grammar ---
+// FlutterParseResult holds the result of parsing a single line of Flutter/Dart output:
+// a compile error or part of a runtime exception.
+type FlutterParseResult struct {
+	Error     *FlutterError  `parser:"( @@ EOL?"`
+	Frame     *DartFrame     `parser:"| @@ EOL?"`
+	Unhandled *DartUnhandled `parser:"| @@ EOL?"`
+	Exception *DartException `parser:"| @@ EOL? )"`
+}
+
+// errLowercaseSeverity rejects `file:line: severity:` lines that are not from the Dart front end.
+var errLowercaseSeverity = errors.New("lowercase severity, not a dart diagnostic")
+
+// normalize splits frame lines into function and location. It rejects compile errors
+// with a lowercase severity: Dart capitalizes it ("Error:"), while C, C++ and Go
+// diagnostics of the same shape ("warning:", "undefined:") don't.
+func (r *FlutterParseResult) normalize() error {
+	if r.Error != nil && strings.ToLower(r.Error.ErrType[:1]) == r.Error.ErrType[:1] {
+		return errLowercaseSeverity
+	}
+	if r.Frame != nil {
+		r.Frame.split()
+	}
+	return nil
+}
+
+// Flutter parser instance
+var flutterParser = participle.MustBuild[FlutterParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,686 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Go Grammar ---
+// Example 1: main.go:1:1: expected 'package', found 'EOF'
+// Example 2: ./main.go:4:2: undefined: fmt
+// go vet and staticcheck use the same shape; staticcheck appends its check code:
+// Example 2b: main.go:12:2: ioutil.ReadFile is deprecated: use os.ReadFile (SA1019)
+// golangci-lint appends the name of the linter instead, and by default prints the
+// offending source line and a caret under the finding, which the Assembler skips:
+// Example 2c: pkg/store/store.go:31:15: Error return value of `f.Close` is not checked (errcheck)
+// gcc reports on the C code of cgo packages in the same shape, see cgoDiagnostic:
+// Example 2d: cgo-gcc-prolog:49:33: warning: passing argument 1 of 'foo' makes pointer from integer without a cast
+// The assembler reports on .s files without a column, and prefixes the errors of its
+// lexer, such as those in macros and #include, with its name:
+// Example 2e: ./sum_amd64.s:12: unrecognized instruction "MOVX"
+// Example 2f: asm: ./sum_amd64.s:30: unexpected EOF
+type GoCompileError struct {
+	Asm      bool   `parser:"@('asm' ':')?"`
+	Filename string `parser:"@(Path | ModulePath | 'cgo' '-' 'gcc' '-' 'prolog')"` // cgo's generated prolog has no extension
+	Line     int    `parser:"':' @Number"`
+	Column   *int   `parser:"(':' @Number)?"` // Omitted by older toolchains and some vet checks
+	Message  Rest   `parser:"':' @@"`
+
+	Check string // staticcheck check code such as SA1019 or golangci-lint linter name ending the message, see normalize
+
+	Pos lexer.Position
+}
+
+// lintCheck matches the trailing check code of a staticcheck diagnostic, from the
+// SA, S, ST, QF and U check groups, or the lowercase word in parentheses golangci-lint
+// appends, which must be one of golangciLinters.
+var lintCheck = regexp.MustCompile(`\s\(((?:SA|S|ST|QF|U)\d{4}|[a-z][a-z0-9-]*)\)$`)
+
+// golangciLinters are the linters golangci-lint names at the end of its findings.
+var golangciLinters = map[string]bool{
+	"asasalint": true, "asciicheck": true, "bidichk": true, "bodyclose": true, "canonicalheader": true,
+	"containedctx": true, "contextcheck": true, "copyloopvar": true, "cyclop": true, "decorder": true,
+	"depguard": true, "dogsled": true, "dupl": true, "dupword": true, "durationcheck": true,
+	"err113": true, "errcheck": true, "errchkjson": true, "errname": true, "errorlint": true,
+	"exhaustive": true, "exhaustruct": true, "exptostd": true, "fatcontext": true, "forbidigo": true,
+	"forcetypeassert": true, "funlen": true, "gci": true, "ginkgolinter": true, "gocheckcompilerdirectives": true,
+	"gochecknoglobals": true, "gochecknoinits": true, "gochecksumtype": true, "gocognit": true, "goconst": true,
+	"gocritic": true, "go-critic": true, "gocyclo": true, "godot": true, "godox": true,
+	"gofmt": true, "gofumpt": true, "goheader": true, "goimports": true, "gomoddirectives": true,
+	"gomodguard": true, "goprintffuncname": true, "gosec": true, "gosimple": true, "gosmopolitan": true,
+	"govet": true, "grouper": true, "iface": true, "importas": true, "inamedparam": true,
+	"ineffassign": true, "interfacebloat": true, "intrange": true, "ireturn": true, "lll": true,
+	"loggercheck": true, "maintidx": true, "makezero": true, "mirror": true, "misspell": true,
+	"mnd": true, "musttag": true, "nakedret": true, "nestif": true, "nilerr": true,
+	"nilnesserr": true, "nilnil": true, "nlreturn": true, "noctx": true, "nolintlint": true,
+	"nonamedreturns": true, "nosprintfhostport": true, "paralleltest": true, "perfsprint": true, "prealloc": true,
+	"predeclared": true, "promlinter": true, "protogetter": true, "reassign": true, "recvcheck": true,
+	"revive": true, "rowserrcheck": true, "sloglint": true, "spancheck": true, "sqlclosecheck": true,
+	"staticcheck": true, "stylecheck": true, "tagalign": true, "tagliatelle": true, "testableexamples": true,
+	"testifylint": true, "testpackage": true, "thelper": true, "tparallel": true, "typecheck": true,
+	"unconvert": true, "unparam": true, "unused": true, "usestdlibvars": true, "usetesting": true,
+	"varnamelen": true, "wastedassign": true, "whitespace": true, "wrapcheck": true, "wsl": true,
+	"zerologlint": true,
+}
+
+// normalize moves a trailing staticcheck "(SA1019)" code or golangci-lint "(errcheck)"
+// linter name from the message into Check. The go command doesn't append either, so the
+// Assembler puts the check back into the message of errors under a "# pkg" header,
+// see withoutCheck.
+func (e *GoCompileError) normalize() {
+	msg := strings.TrimSpace(string(e.Message))
+	if m := lintCheck.FindStringSubmatchIndex(msg); m != nil {
+		check := msg[m[2]:m[3]]
+		if check[0] < 'a' || golangciLinters[check] {
+			e.Check = check
+			msg = msg[:m[0]]
+		}
+	}
+	e.Message = Rest(msg)
+}
+
+// withoutCheck returns the error with its check put back at the end of the message,
+// for output of the go command whose message merely ends in a word in parentheses.
+func (e *GoCompileError) withoutCheck() *GoCompileError {
+	if e.Check == "" {
+		return e
+	}
+	c := *e
+	c.Message = Rest(string(e.Message) + " (" + e.Check + ")")
+	c.Check = ""
+	return &c
+}
+
+func (e *GoCompileError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     "Error", // Go compiler errors are typically just "Error"
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if isModFile(e.Filename) {
+		info.Type = "ModuleError" // e.g. go.mod:5: unknown directive: requre
+	}
+	if e.Check != "" {
+		info.Type = "Warning" // Lint findings don't break the build
+		info.Code = e.Check
+	}
+	return info
+}
+
+// gccSeverities are the message prefixes of gcc diagnostics, see cgoDiagnostic.
+var gccSeverities = []string{"fatal error", "error", "warning", "note"}
+
+// cgoDiagnostic returns the gcc diagnostic the compile error is if its message starts
+// with a gcc severity. cgo runs gcc on the C code of a package (the preamble of a Go
+// file, its .c files and the _cgo_ sources it generates), whose errors appear among
+// the Go ones.
+func (e *GoCompileError) cgoDiagnostic() (*CppDiagnostic, bool) {
+	if e == nil {
+		return nil, false
+	}
+	msg := strings.TrimSpace(string(e.Message))
+	for _, severity := range gccSeverities {
+		if text, ok := strings.CutPrefix(msg, severity+": "); ok {
+			d := &CppDiagnostic{
+				Filename: e.Filename,
+				Line:     e.Line,
+				Column:   e.Column,
+				Severity: strings.TrimSuffix(severity, " error"), // "fatal error" -> "fatal", as CppDiagnostic has it
+				Message:  Rest(text),
+				Pos:      e.Pos,
+			}
+			return d, d.normalize() == nil
+		}
+	}
+	return nil, false
+}
+
+// isGolangciLint reports whether the finding came from golangci-lint, which names
+// linters in lowercase where staticcheck uses check codes.
+func (e *GoCompileError) isGolangciLint() bool {
+	return e.Check != "" && e.Check[0] >= 'a' && e.Check[0] <= 'z'
+}
+
+// isContinuationLine reports whether line continues the compile error before it, such
+// as the "have" and "want" lines of a call with the wrong arguments, which the compiler
+// indents under the error:
+//
+//	./main.go:12:5: not enough arguments in call to greet
+//		have (string)
+//		want (string, int)
+func isContinuationLine(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
+
+// isCaretLine reports whether line only holds the "^" golangci-lint prints under a finding.
+func isCaretLine(line string) bool {
+	return strings.TrimSpace(line) == "^"
+}
+
+// Example: # example.com/foo
+// go build and go vet print the package before its errors; test builds add the
+// test variant, e.g. # example.com/foo [example.com/foo.test], and go vet follows
+// the header with the package in brackets, # [example.com/foo].
+// The Assembler attaches the package to the compile errors that follow.
+type GoPackageHeader struct {
+	Package Rest `parser:"'#' @@"`
+
+	Pos lexer.Position
+}
+
+// errNotGoPackage rejects "#" lines that don't name a package, e.g. comments in a script.
+var errNotGoPackage = errors.New("not a Go package header")
+
+// normalize keeps the import path and drops the bracketed test variant.
+func (h *GoPackageHeader) normalize() error {
+	fields := strings.Fields(string(h.Package))
+	if len(fields) == 0 || len(fields) > 2 || (len(fields) == 2 && !strings.HasPrefix(fields[1], "[")) {
+		return errNotGoPackage
+	}
+	h.Package = Rest(strings.TrimSuffix(strings.TrimPrefix(fields[0], "["), "]")) // go vet's # [pkg]
+	return nil
+}
+
+// Example 3: panic: runtime error: integer divide by zero
+// Followed by the goroutine header and stack frames, e.g.:
+//
+//	goroutine 1 [running]:
+//	main.main()
+//		/home/dima/projects/errorparser/main.go:9 +0x8d
+//
+// Frames span several lines, so the Assembler accumulates them into Frames.
+type GoPanic struct {
+	Message Rest `parser:"PanicStart @@"` // Capture message after "panic:"
+
+	Signal    string         // Signal line printed for runtime faults, see GoSignal
+	Goroutine *int           // ID from the goroutine header, filled in by the Assembler
+	Frames    []GoStackFrame // Filled in by the Assembler from the following lines
+	Omitted   int            // Frames dropped to respect the Assembler's frame limit, see addFrame
+
+	Pos lexer.Position
+}
+
+// addFrame appends the next frame of the stack, keeping at most limit frames (no limit
+// if it is 0). Further frames are dropped and counted in Omitted, except that the top
+// user frame replaces the last retained frame when the stack holds only runtime frames,
+// so the location of the panic survives the limit.
+func (e *GoPanic) addFrame(frame GoStackFrame, limit int) {
+	if limit <= 0 || len(e.Frames) < limit {
+		e.Frames = append(e.Frames, frame)
+		return
+	}
+	e.Omitted++
+	if _, user := userGoFrame(e.Frames); !frame.isRuntime() && !user {
+		e.Frames[limit-1] = frame
+	}
+}
+
+// GoStackFrame is one function call of a Go stack trace.
+type GoStackFrame struct {
+	Function string // e.g. main.main() or main.(*Server).handle(0xc000010000)
+	File     string
+	Line     int
+	Offset   string // Instruction offset such as +0x8d, empty if not printed
+}
+
+// isRuntime reports whether the frame belongs to the Go runtime rather than user code.
+func (f GoStackFrame) isRuntime() bool {
+	return strings.HasPrefix(f.Function, "runtime.") || strings.HasPrefix(f.Function, "panic(")
+}
+
+func (e *GoPanic) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos:  parsePos(e.Pos),
+		Type:      "Panic",
+		Message:   strings.TrimSpace(string(e.Message)),
+		Goroutine: e.Goroutine,
+		Frames:    goFrames(e.Frames),
+
+		OmittedFrames: e.Omitted,
+	}
+	if e.Signal != "" {
+		info.Message += " [" + e.Signal + "]"
+	}
+	// Promote the top user frame (first non-runtime frame) into the location
+	if frame, ok := e.topFrame(); ok {
+		info.Filename = frame.File
+		info.Line = frame.Line
+	}
+	return info
+}
+
+// goFrames converts a Go stack, innermost call first, into Frames, outermost call first.
+func goFrames(stack []GoStackFrame) []Frame {
+	var frames []Frame
+	for i := len(stack) - 1; i >= 0; i-- {
+		frames = append(frames, Frame{Filename: stack[i].File, Line: stack[i].Line, Function: stack[i].Function})
+	}
+	return frames
+}
+
+// topFrame returns the first non-runtime frame, falling back to the innermost frame.
+func (e *GoPanic) topFrame() (GoStackFrame, bool) {
+	return topGoFrame(e.Frames)
+}
+
+// topGoFrame returns the first non-runtime frame of a stack, falling back to the innermost frame.
+func topGoFrame(frames []GoStackFrame) (GoStackFrame, bool) {
+	if frame, ok := userGoFrame(frames); ok {
+		return frame, true
+	}
+	if len(frames) > 0 {
+		return frames[0], true
+	}
+	return GoStackFrame{}, false
+}
+
+// userGoFrame returns the first non-runtime frame of a stack.
+func userGoFrame(frames []GoStackFrame) (GoStackFrame, bool) {
+	for _, frame := range frames {
+		if !frame.isRuntime() {
+			return frame, true
+		}
+	}
+	return GoStackFrame{}, false
+}
+
+// Example: [signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x48f0e5]
+// Printed between the panic message and the goroutine header for runtime faults.
+type GoSignal struct {
+	Text Rest `parser:"LBracket 'signal' @@"`
+
+	Pos lexer.Position
+}
+
+// Example: goroutine 1 [running]:
+// Starts the stack trace of a goroutine.
+type GoGoroutine struct {
+	ID    int  `parser:"'goroutine' @Number"`
+	State Rest `parser:"@@"` // e.g. [running]:
+
+	Pos lexer.Position
+}
+
+// Example: /home/dima/projects/errorparser/main.go:9 +0x8d
+// The file line of a stack frame; the function line before it is free-form.
+type GoStackLocation struct {
+	File   string `parser:"@(Path | ModulePath)"`
+	Line   int    `parser:"':' @Number"`
+	Offset string `parser:"@Offset?"`
+
+	Pos lexer.Position
+}
+
+// isGoStackCall reports whether a line looks like the function line of a stack frame,
+// e.g. main.main() or created by main.main in goroutine 1.
+func isGoStackCall(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasSuffix(line, ")") || strings.HasPrefix(line, "created by ")
+}
+
+// Example: WARNING: DATA RACE
+// Printed by the race detector (go test -race), followed by the two conflicting accesses
+// and the goroutines involved, each with its stack, up to a line of "=" characters:
+//
+//	Read at 0x00c0000a0010 by goroutine 7:
+//	  main.(*Counter).Inc()
+//	      /home/dev/app/counter.go:12 +0x3a
+//
+//	Previous write at 0x00c0000a0010 by goroutine 6:
+//	  ...
+//	Goroutine 7 (running) created at:
+//	  ...
+//
+// The Assembler collects the blocks into a GoRace.
+type GoRaceHeader struct {
+	Header bool `parser:"@('WARNING' ':' 'DATA' 'RACE')"`
+
+	Pos lexer.Position
+}
+
+// GoRaceAccess is the "Read at 0x... by goroutine N:" line starting one of the conflicting accesses.
+type GoRaceAccess struct {
+	Previous bool   `parser:"@'Previous'?"`
+	Kind     string `parser:"@('Read' | 'Write' | 'read' | 'write')"`
+	Detail   Rest   `parser:"'at' @@"` // e.g. 0x00c0000a0010 by goroutine 7:
+
+	Frames []GoStackFrame // Filled in by the Assembler
+
+	Pos lexer.Position
+}
+
+// String describes the access without its address, e.g. "previous write by goroutine 6".
+func (r GoRaceAccess) String() string {
+	text := strings.ToLower(r.Kind)
+	if r.Previous {
+		text = "previous " + text
+	}
+	if fields := strings.Fields(string(r.Detail)); len(fields) > 1 {
+		text += " " + strings.TrimSuffix(strings.Join(fields[1:], " "), ":")
+	}
+	return text
+}
+
+// raceGoroutine matches the goroutine of an access, e.g. "by goroutine 7:".
+var raceGoroutine = regexp.MustCompile(`by goroutine (\d+)`)
+
+// goroutine returns the ID of the goroutine making the access, nil for e.g. "by main goroutine".
+func (r GoRaceAccess) goroutine() *int {
+	m := raceGoroutine.FindStringSubmatch(string(r.Detail))
+	if m == nil {
+		return nil
+	}
+	id, _ := strconv.Atoi(m[1])
+	return &id
+}
+
+// GoRaceGoroutine is the "Goroutine N (running) created at:" line of a race report.
+// Its stack shows where the goroutine was started, not the racing access.
+type GoRaceGoroutine struct {
+	ID    int  `parser:"'Goroutine' @Number"`
+	State Rest `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// GoRace is a complete data race report, assembled from a GoRaceHeader and the lines after it.
+type GoRace struct {
+	Accesses []GoRaceAccess
+	inAccess bool // Whether frames belong to the latest access rather than a goroutine creation
+
+	Pos lexer.Position // Position of the GoRaceHeader, set by the Assembler
+}
+
+// ToErrorInfo converts a GoRace into the common ErrorInfo format. The top user frame of the
+// first access is the location; every access with its location is listed in Notes.
+func (r *GoRace) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{Type: "DataRace", Message: "data race", ParsePos: parsePos(r.Pos)}
+	var parts []string
+	for i, access := range r.Accesses {
+		parts = append(parts, access.String())
+		note := access.String()
+		if frame, ok := topGoFrame(access.Frames); ok {
+			note += " at " + frame.File + ":" + strconv.Itoa(frame.Line)
+			if i == 0 {
+				info.Filename, info.Line = frame.File, frame.Line
+			}
+		}
+		info.Notes = append(info.Notes, note)
+	}
+	if len(parts) > 0 {
+		info.Message += ": " + strings.Join(parts, " conflicts with ")
+	}
+	if len(r.Accesses) > 0 {
+		info.Frames = goFrames(r.Accesses[0].Frames)
+		info.Goroutine = r.Accesses[0].goroutine()
+	}
+	return info
+}
+
+// Example 4: --- FAIL: TestFoo (0.00s)
+// Printed by `go test` for each failing test; subtests use slash-separated names.
+type GoTestFailure struct {
+	Name     string `parser:"TestFailStart @(Path | Word)"` // e.g. TestFoo or TestFoo/subcase
+	Duration string `parser:"( '(' @Path ')' )?"`           // e.g. 0.00s
+
+	Pos lexer.Position
+}
+
+func (e *GoTestFailure) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     "TestFailure",
+		Message:  e.Name + " failed",
+	}
+}
+
+// Example 5:     foo_test.go:42: expected 3, got 4
+// Indented log/assertion lines that follow a `--- FAIL` header. Unlike compile errors there is no column.
+type GoTestAssertion struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Message  Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+func (e *GoTestAssertion) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Type:     "TestFailure",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// Example: /usr/bin/ld: cannot find -lfoo: No such file or directory
+// Example: /home/me/app/bridge.c:12: undefined reference to `bar'
+// Example: collect2: error: ld returned 1 exit status
+// Linker errors show up in cgo builds. Their shapes vary too much for the grammar, so the
+// whole line is captured and classified after parsing. "in function" lines and the final
+// collect2 summary are context; the others become LinkError.
+type GoLinkError struct {
+	Text Rest `parser:"@@"`
+
+	Filename string // Object or source file the error refers to, if printed
+	Line     int
+	Message  string
+	Context  bool // A line giving context for the errors around it rather than an error
+
+	Pos lexer.Position
+}
+
+var (
+	// linkTool matches the linker or compiler driver prefix, e.g. "/usr/bin/ld: " or "collect2: ".
+	linkTool = regexp.MustCompile(`^(?:\S*/)?(?:ld(?:\.\w+)?|lld|ld64\.lld|collect2)(?:\.exe)?: (.*)$`)
+	// linkReference matches a located reference error, e.g. "main.c:5: undefined reference to `bar'"
+	// or "main.o:(.text+0x1e): undefined reference to `bar'".
+	linkReference = regexp.MustCompile(`^(\S+?):(?:(\d+)|\(\S+\)):\s*((?:undefined reference to|multiple definition of) .*)$`)
+	// linkFunction matches the "file: in function `f':" line preceding reference errors.
+	linkFunction = regexp.MustCompile(`^\S+: [Ii]n function `)
+
+	errNotLinkError = errors.New("not a linker error")
+)
+
+// normalize classifies the line and splits off the location, see GoLinkError.
+func (e *GoLinkError) normalize() error {
+	text := strings.TrimSpace(string(e.Text))
+	tool := linkTool.FindStringSubmatch(text)
+	if tool != nil {
+		text = tool[1]
+	}
+	switch m := linkReference.FindStringSubmatch(text); {
+	case m != nil:
+		e.Filename, e.Message = m[1], m[3]
+		e.Line, _ = strconv.Atoi(m[2]) // Zero for object file offsets
+	case tool == nil:
+		return errNotLinkError
+	case linkFunction.MatchString(text), strings.HasPrefix(text, "error: ld returned"):
+		e.Context = true
+	default:
+		e.Message = text
+	}
+	return nil
+}
+
+// ToErrorInfo converts a GoLinkError into the common ErrorInfo format.
+func (e *GoLinkError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Type:     "LinkError",
+		Message:  e.Message,
+	}
+}
+
+// isLinkMessage reports whether a `file:line: message` line is a linker reference error
+// rather than a test assertion.
+func isLinkMessage(msg string) bool {
+	msg = strings.TrimSpace(msg)
+	return strings.HasPrefix(msg, "undefined reference to ") || strings.HasPrefix(msg, "multiple definition of ")
+}
+
+// Example 1: cgo:
+// Example 2: gcc errors for preamble:
+// Example 3: cgo-gcc-prolog: In function '_cgo_5f2a1b3c4d5e_Cfunc_foo':
+// cgo frames the gcc diagnostics for the C code of a package with these lines.
+// They carry no location and are recognized without reporting anything.
+type GoCgoContext struct {
+	Text Rest `parser:"( 'cgo' ( '-' 'gcc' '-' 'prolog' )? ':' | 'gcc' 'errors' 'for' 'preamble' ':' ) @@"`
+}
+
+// Example 1: go: example.com/foo@v1.2.3: reading https://proxy.golang.org/example.com/foo/@v/v1.2.3.mod: 404 Not Found
+// Example 2: go: updates to go.mod needed; to update it:
+// Example 3: go: warning: "all" matched no packages
+// Example 4: go: /home/dev/project/go.mod:5: unknown directive: requre
+// The go command reports module resolution and go.mod problems with its name as the
+// prefix, usually without a location. Details follow on indented lines, which the
+// Assembler folds into the message like those of compile errors.
+type GoModuleError struct {
+	Warning  bool   `parser:"'go' ':' @('warning' ':')?"`
+	Filename string `parser:"( @Path ':'"`
+	Line     int    `parser:"  @Number ':'"`
+	Column   *int   `parser:"  (@Number ':')? )?"`
+	Message  Rest   `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a parsed GoModuleError into the common ErrorInfo format.
+func (e *GoModuleError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     "ModuleError",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if e.Warning {
+		info.Type = "Warning"
+	}
+	return info
+}
+
+// Example 1: go: downloading example.com/foo v1.2.3
+// Example 2: go: finding module for package example.com/foo/bar
+// Example 3: go: errors parsing go.mod:
+// The go command also reports its progress with its name as the prefix. These lines,
+// and the one announcing the located errors of a go.mod file, report nothing.
+type GoModuleNotice struct {
+	Action string `parser:"'go' ':' @('downloading' | 'extracting' | 'finding' | 'found' | 'added' | 'upgraded' | 'downgraded' | 'removed' | 'creating' | 'errors' 'parsing')"`
+	Detail Rest   `parser:"@@"`
+}
+
+// isModFile reports whether filename is a go.mod, go.work or go.sum file, whose
+// errors are reported as ModuleError.
+func isModFile(filename string) bool {
+	base := filename[strings.LastIndexAny(filename, `/\`)+1:]
+	return base == "go.mod" || base == "go.work" || base == "go.sum"
+}
+
+// errNotGoToolFile rejects column-less errors in files the go command doesn't report
+// on, such as "config.yml:3: note", see isGoToolFile.
+var errNotGoToolFile = errors.New("not a file of the go command")
+
+// isGoToolFile reports whether filename is one the go command prints column-less errors
+// for: a Go source, a go.mod, go.work or go.sum file, or cgo's generated prolog.
+// Assembly sources are handled by isAsm.
+func isGoToolFile(filename string) bool {
+	return strings.HasSuffix(filename, ".go") || isModFile(filename) || filename == "cgo-gcc-prolog"
+}
+
+// Example 1: asm: assembly of ./sum_amd64.s failed
+// Example 2: asm: too many errors
+// go build ends the assembler's diagnostics with one of these lines. They are
+// recognized without reporting anything, as the diagnostics locate the errors.
+type GoAsmFailure struct {
+	File string `parser:"'asm' ':' ( 'assembly' 'of' @Path 'failed' | 'too' 'many' 'errors' )"`
+}
+
+// isAsm reports whether the error is the assembler's, which never prints a column.
+func (e *GoCompileError) isAsm() bool {
+	return e.Asm || strings.HasSuffix(e.Filename, ".s")
+}
+
+// --- Go Specific Grammar ---
+// GoParseResult holds the result of parsing a single line of Go output.
+type GoParseResult struct {
+	CompileError  *GoCompileError  `parser:"( @@ EOL?"`
+	TestAssertion *GoTestAssertion // Column-less CompileError turned into an assertion, see normalize
+	Cgo           *CppDiagnostic   // CompileError that is a gcc diagnostic, see normalize
+	StackLocation *GoStackLocation `parser:"| @@ EOL?"`
+	Panic         *GoPanic         `parser:"| @@ EOL?"`
+	Goroutine     *GoGoroutine     `parser:"| @@ EOL?"`
+	Signal        *GoSignal        `parser:"| @@ EOL?"`
+	TestFailure   *GoTestFailure   `parser:"| @@ EOL?"`
+	Package       *GoPackageHeader `parser:"| @@ EOL?"`
+	RaceHeader    *GoRaceHeader    `parser:"| @@ EOL?"`
+	RaceAccess    *GoRaceAccess    `parser:"| @@ EOL?"`
+	RaceGoroutine *GoRaceGoroutine `parser:"| @@ EOL?"`
+	RaceEnd       bool             `parser:"| @('=' '='+) EOL?"` // Line of "=" around a race report
+	CgoContext    *GoCgoContext    `parser:"| @@ EOL?"`
+	ModuleNotice  *GoModuleNotice  `parser:"| @@ EOL?"`
+	Module        *GoModuleError   `parser:"| @@ EOL?"`
+	AsmFailure    *GoAsmFailure    `parser:"| @@ EOL?"`
+	Link          *GoLinkError     `parser:"| @@ EOL? )"` // Must stay last, it accepts any line
+}
+
+// normalize post-processes the parsed line, see GoCompileError.normalize, GoPackageHeader.normalize
+// and GoLinkError.normalize. Reference errors with a line number parse like test assertions
+// and are turned into link errors here, and gcc diagnostics for cgo code into Cgo.
+func (r *GoParseResult) normalize() error {
+	if c := r.CompileError; c != nil && c.Column == nil && !c.isAsm() {
+		// A `file:line: message` line without a column is a linker error, a test
+		// assertion (indented under its test, or in a _test.go file) or a compile error.
+		// Other tools print the same shape for any file, so apart from linker and gcc
+		// errors it must name a file the go command reports on.
+		switch {
+		case isLinkMessage(string(c.Message)):
+			r.Link = &GoLinkError{Filename: c.Filename, Line: c.Line, Message: strings.TrimSpace(string(c.Message)), Pos: c.Pos}
+			r.CompileError = nil
+			return nil
+		case !isGoToolFile(c.Filename):
+			if d, ok := c.cgoDiagnostic(); ok {
+				r.Cgo, r.CompileError = d, nil
+				return nil
+			}
+			return errNotGoToolFile
+		case c.Pos.Column > 1 || strings.HasSuffix(c.Filename, "_test.go"):
+			r.TestAssertion = &GoTestAssertion{Filename: c.Filename, Line: c.Line, Message: c.Message, Pos: c.Pos}
+			r.CompileError = nil
+			return nil
+		}
+	}
+	if d, ok := r.CompileError.cgoDiagnostic(); ok {
+		r.Cgo, r.CompileError = d, nil
+		return nil
+	}
+	if r.CompileError != nil {
+		r.CompileError.normalize()
+	}
+	if r.Package != nil {
+		return r.Package.normalize()
+	}
+	if r.Link != nil {
+		return r.Link.normalize()
+	}
+	return nil
+}
+
+// Go parser instance
+// Several alternatives start with a path, so the parser needs enough lookahead to
+// backtrack once the tokens after it don't fit.
+var goParser = participle.MustBuild[GoParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// --- Go JSON Events ---
+// Example 1: {"Time":"2024-05-01T10:00:00Z","Action":"output","Package":"example.com/calc","Test":"TestAdd","Output":"    calc_test.go:42: expected 3, got 4\n"}
+// Example 2: {"ImportPath":"example.com/calc","Action":"build-output","Output":"calc/calc.go:21:2: declared and not used: tmp\n"}
+// `go test -json` (test2json) and `go build -json` (Go 1.21+) wrap every line the toolchain
+// prints in a JSON event. The Assembler unwraps the Output of each event and parses it as
+// Go text output, see Assembler.feedGoJSON.
+type GoJSONEvent struct {
+	Action     string // e.g. output, build-output, run, pass, fail, build-fail
+	Package    string // Package of a test event
+	ImportPath string // Package of a build event, e.g. "example.com/calc [example.com/calc.test]"
+	Test       string // Test of a test event, empty for package level events
+	Output     string // Printed text, usually one line including its newline
+}
+
+// errNotGoJSONEvent rejects lines that are not JSON objects with an Action.
+var errNotGoJSONEvent = errors.New("not a go -json event")
+
+// parseGoJSONEvent decodes one line of go -json output.
+func parseGoJSONEvent(line string) (*GoJSONEvent, error) {
+	var ev GoJSONEvent
+	if err := json.Unmarshal([]byte(line), &ev); err != nil {
+		return nil, err
+	}
+	if ev.Action == "" {
+		return nil, errNotGoJSONEvent
+	}
+	return &ev, nil
+}
+
+// pkg returns the package the event belongs to.
+func (e *GoJSONEvent) pkg() string {
+	if e.Package != "" {
+		return e.Package
+	}
+	path, _, _ := strings.Cut(e.ImportPath, " ") // Drop the " [pkg.test]" variant suffix
+	return path
+}
+
+// ends reports whether the event finishes its package, after which no more output follows.
+func (e *GoJSONEvent) ends() bool {
+	if e.Test != "" {
+		return false
+	}
+	switch e.Action {
+	case "pass", "fail", "skip", "build-fail":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Haskell Grammar (GHC) ---
+// Example 1: src/Main.hs:10:5: error: Variable not in scope: foo
+// Example 2: src/Main.hs:10:5-7: error: [GHC-88464]
+// Example 3: src/Lib.hs:(4,1)-(6,20): warning: [-Wincomplete-patterns]
+// GHC usually puts the message on the indented lines after the header:
+//
+//	src/Main.hs:10:5: error: [GHC-88464]
+//	    • Variable not in scope: foo :: IO ()
+//	    • Perhaps you meant ‘for’ (imported from Prelude)
+//	   |
+//	10 |     foo
+//	   |     ^^^
+//
+// The Assembler folds those continuation lines into Details.
+type HaskellError struct {
+	Filename string        `parser:"@Path ':'"`
+	Point    *HaskellPoint `parser:"( @@"`
+	Span     *HaskellSpan  `parser:"| @@ )"`
+	Severity string        `parser:"':' @('error' | 'warning')"`
+	Message  Rest          `parser:"':' @@"`
+
+	Code    string   // GHC error code or warning flag, e.g. GHC-88464 or -Wunused-imports
+	Details []string // Continuation lines, filled in by the Assembler
+
+	Pos lexer.Position
+}
+
+// HaskellPoint is a line:col location, optionally with an end column on the same line.
+type HaskellPoint struct {
+	Line      int  `parser:"@Number"`
+	Column    int  `parser:"':' @Number"`
+	EndColumn *int `parser:"( '-' @Number )?"`
+}
+
+// HaskellSpan is a multi-line (line,col)-(line,col) location.
+type HaskellSpan struct {
+	Line      int `parser:"LParen @Number"`
+	Column    int `parser:"',' @Number RParen"`
+	EndLine   int `parser:"'-' LParen @Number"`
+	EndColumn int `parser:"',' @Number RParen"`
+}
+
+// errNotHaskellFile rejects diagnostics for non-Haskell sources (e.g. "main.cpp:1:2: error: ...").
+var errNotHaskellFile = errors.New("not a Haskell source file")
+
+// normalize checks the file type and moves leading "[GHC-88464]" / "[-Wflag]" tags from the
+// message into Code, keeping the first one: GHC prints its error code before the flag.
+func (e *HaskellError) normalize() error {
+	if !strings.HasSuffix(e.Filename, ".hs") && !strings.HasSuffix(e.Filename, ".lhs") && !strings.HasSuffix(e.Filename, ".hsc") {
+		return errNotHaskellFile
+	}
+	msg := strings.TrimSpace(string(e.Message))
+	for strings.HasPrefix(msg, "[") {
+		end := strings.IndexByte(msg, ']')
+		if end < 0 {
+			break
+		}
+		if e.Code == "" {
+			e.Code = msg[1:end]
+		}
+		msg = strings.TrimSpace(msg[end+1:])
+	}
+	e.Message = Rest(msg)
+	return nil
+}
+
+// isHaskellExcerpt reports whether a line belongs to GHC's source excerpt ("   |", "10 |     foo").
+func isHaskellExcerpt(line string) bool {
+	gutter, _, ok := strings.Cut(line, "|")
+	return ok && strings.Trim(gutter, "0123456789 ") == ""
+}
+
+// isHaskellContinuation reports whether a line continues the diagnostic above it:
+// an indented detail line or a line of the source excerpt.
+func isHaskellContinuation(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || isHaskellExcerpt(line)
+}
+
+// addDetail appends a continuation line to Details. Source excerpt lines are skipped
+// and GHC's "•" bullets start a new detail; other lines continue the previous one.
+func (e *HaskellError) addDetail(line string) {
+	text := strings.TrimSpace(line)
+	if text == "" || isHaskellExcerpt(line) {
+		return
+	}
+	bullet, ok := strings.CutPrefix(text, "•")
+	if ok || len(e.Details) == 0 {
+		e.Details = append(e.Details, strings.TrimSpace(bullet))
+		return
+	}
+	e.Details[len(e.Details)-1] += " " + text
+}
+
+// ToErrorInfo converts a parsed HaskellError, including its detail lines, into the
+// common ErrorInfo format. Details are joined with "; " after the header message.
+func (e *HaskellError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Type:     capitalize(e.Severity), // "error" -> "Error"
+		Code:     e.Code,
+		Message:  strings.Join(append([]string{string(e.Message)}, e.Details...), "; "),
+	}
+	info.Message = strings.TrimPrefix(info.Message, "; ") // Header without a message
+	switch {
+	case e.Point != nil:
+		col := e.Point.Column
+		info.Line, info.Column = e.Point.Line, &col
+		if e.Point.EndColumn != nil {
+			line := e.Point.Line // Range ends on the same line
+			info.EndLine, info.EndColumn = &line, e.Point.EndColumn
+		}
+	case e.Span != nil:
+		s := *e.Span
+		info.Line, info.Column = s.Line, &s.Column
+		info.EndLine, info.EndColumn = &s.EndLine, &s.EndColumn
+	}
+	return info
+}
+
+// Haskell parser instance
+var haskellParser = participle.MustBuild[HaskellError](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Java Grammar ---
+// Example 1: Exception in thread "main" java.lang.NullPointerException: msg
+// Example 2: 	at com.example.Foo.bar(Foo.java:42)
+// Example 3: Caused by: java.lang.IllegalStateException: inner
+// Example 4: 	... 3 more
+// The header names the exception; the tab-indented `at` frames and "Caused by:" chain
+// follow on their own lines and are attached by the Assembler.
+
+// JavaException captures the exception class and optional message of a stack trace header.
+type JavaException struct {
+	Thread  string `parser:"( 'Exception' 'in' 'thread' @String )?"` // Quoted thread name, if printed
+	Class   string `parser:"@Path"`                                  // e.g. java.lang.NullPointerException
+	Message Rest   `parser:"( ':' @@ )?"`
+
+	Frames []JavaFrame // Filled in by the Assembler from the following `at` lines
+	Causes []JavaCause // Filled in by the Assembler from "Caused by:" lines
+
+	Pos lexer.Position
+}
+
+// errNotJavaClass rejects header lines whose class is not a Java class name (e.g. "main.cpp: ...").
+var errNotJavaClass = errors.New("not a Java exception class name")
+
+// isJavaClassName reports whether name looks like a fully qualified Java class,
+// e.g. java.lang.NullPointerException: dotted, without path separators, capitalized last segment.
+func isJavaClassName(name string) bool {
+	dot := strings.LastIndexByte(name, '.')
+	if dot < 0 || dot == len(name)-1 || strings.ContainsAny(name, `/\`) {
+		return false
+	}
+	return unicode.IsUpper(rune(name[dot+1]))
+}
+
+// normalize rejects exception headers whose class is not a Java class name.
+func (r *JavaParseResult) normalize() error {
+	if r.Exception != nil && !isJavaClassName(r.Exception.Class) {
+		return errNotJavaClass
+	}
+	return nil
+}
+
+// JavaCause is a "Caused by:" continuation of a Java exception.
+type JavaCause struct {
+	Class   string `parser:"'Caused' 'by' ':' @Path"`
+	Message Rest   `parser:"( ':' @@ )?"`
+
+	Frames []JavaFrame // Filled in by the Assembler
+
+	Pos lexer.Position
+}
+
+// JavaFrame is one `at` line of a Java stack trace.
+// The location is absent for frames like `at Foo.bar(Native Method)`.
+type JavaFrame struct {
+	Method string `parser:"'at' @(~'(')+ '('"` // e.g. com.example.Foo.bar
+	File   string `parser:"( @Path"`
+	Line   int    `parser:"  ':' @Number )?"`
+	Tail   Rest   `parser:"@@"` // Closing parenthesis or e.g. "Native Method)"
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a JavaException, including its frames and causes, into the common ErrorInfo format.
+// The first frame with a location becomes the error location. Frames without one, e.g.
+// "(Native Method)", are left out of Frames, and so are the frames of the causes.
+func (e *JavaException) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     e.Class,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	for _, frame := range e.Frames {
+		if frame.File == "" {
+			continue
+		}
+		info.Frames = append(info.Frames, Frame{Filename: frame.File, Line: frame.Line, Function: strings.TrimSpace(frame.Method)})
+		if info.Filename == "" {
+			info.Filename = frame.File
+			info.Line = frame.Line
+		}
+	}
+	// Java prints the innermost call first; Frames lists the outermost call first
+	for i, j := 0, len(info.Frames)-1; i < j; i, j = i+1, j-1 {
+		info.Frames[i], info.Frames[j] = info.Frames[j], info.Frames[i]
+	}
+	for _, cause := range e.Causes {
+		causeText := cause.Class
+		if msg := strings.TrimSpace(string(cause.Message)); msg != "" {
+			causeText += ": " + msg
+		}
+		if info.Message != "" {
+			info.Message += "; "
+		}
+		info.Message += "caused by " + causeText
+	}
+	return info
+}
+
+// --- Java Specific Grammar ---
+// JavaParseResult holds the result of parsing a single line of Java output.
+type JavaParseResult struct {
+	Cause     *JavaCause     `parser:"( @@ EOL?"`
+	Frame     *JavaFrame     `parser:"| @@ EOL?"`
+	More      int            `parser:"| '...' @Number 'more' EOL?"` // Frames elided as identical to the enclosing trace
+	Exception *JavaException `parser:"| @@ EOL? )"`
+}
+
+// Java parser instance
+var javaParser = participle.MustBuild[JavaParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
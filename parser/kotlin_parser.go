@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Kotlin Grammar (kotlinc) ---
+// Example 1: Main.kt:10:5: error: unresolved reference: foo
+// Example 2: app/src/main/kotlin/com/example/App.kt:3:9: warning: variable 'x' is never used
+// Example 3: build.gradle.kts:12:1: note: this declaration is deprecated
+// Same shape as Flutter, but the severity is lowercase and only Kotlin sources are accepted.
+type KotlinError struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   int    `parser:"':' @Number"`
+	Severity string `parser:"':' @('error' | 'warning' | 'note')"`
+	Message  Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// errNotKotlinFile rejects diagnostics for non-Kotlin sources (e.g. "main.cpp:1:2: error: ...").
+var errNotKotlinFile = errors.New("not a Kotlin source file")
+
+func (e *KotlinError) normalize() error {
+	if !strings.HasSuffix(e.Filename, ".kt") && !strings.HasSuffix(e.Filename, ".kts") {
+		return errNotKotlinFile
+	}
+	return nil
+}
+
+// ToErrorInfo converts a parsed KotlinError into the common ErrorInfo format.
+func (e *KotlinError) ToErrorInfo() ErrorInfo {
+	col := e.Column
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   &col,
+		Type:     capitalize(e.Severity), // "error" -> "Error"
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// --- Kotlin Grammar (Gradle and the Android Gradle Plugin) ---
+// Example 1: e: file:///home/me/app/src/main/java/com/example/Main.kt:10:5 Unresolved reference: foo
+// Example 2: w: file:///C:/work/app/build.gradle.kts:7:5 'kotlinOptions' is deprecated
+// Example 3: e: /home/me/app/src/main/java/com/example/Main.kt: (10, 5): Unresolved reference: foo
+// The Kotlin Gradle plugin prefixes the severity with a single letter. Kotlin 1.8 and
+// later print the file as a file:// URI, older releases a path and a "(line, col)".
+type KotlinGradleError struct {
+	Severity string         `parser:"@('e' | 'w') ':'"`
+	Filename string         `parser:"@(FileURI | Path)"`
+	Line     *int           `parser:"( ':' @Number"`
+	Column   *int           `parser:"  ( ':' @Number )?"`
+	Paren    *ParenLocation `parser:"| ':' @@ ':' )"` // Older releases
+	Message  Rest           `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// normalize turns a file:// URI into a path, see filePath.
+func (e *KotlinGradleError) normalize() error {
+	e.Filename = filePath(e.Filename)
+	return nil
+}
+
+// filePath converts a file:// URI into a path, decoding escapes such as %20. The
+// leading slash of a Windows drive (file:///C:/app) is dropped. Other paths are
+// returned unchanged.
+func filePath(uri string) string {
+	path, ok := strings.CutPrefix(uri, "file://")
+	if !ok {
+		return uri
+	}
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	if decoded, err := url.PathUnescape(path); err == nil {
+		path = decoded
+	}
+	return path
+}
+
+// ToErrorInfo converts a parsed KotlinGradleError into the common ErrorInfo format.
+func (e *KotlinGradleError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Column:   e.Column,
+		Type:     "Error",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if e.Severity == "w" {
+		info.Type = "Warning"
+	}
+	switch {
+	case e.Line != nil:
+		info.Line = *e.Line
+	case e.Paren != nil:
+		info.Line, info.Column = e.Paren.Line, e.Paren.Column
+	}
+	return info
+}
+
+// --- Kotlin Specific Grammar ---
+// KotlinParseResult holds the result of parsing a single line of Kotlin output:
+// a kotlinc diagnostic or one printed by Gradle.
+type KotlinParseResult struct {
+	Error  *KotlinError       `parser:"( @@ EOL?"`
+	Gradle *KotlinGradleError `parser:"| @@ EOL? )"`
+}
+
+// normalize dispatches to the normalize method of the matched alternative.
+func (r *KotlinParseResult) normalize() error {
+	if r.Error != nil {
+		return r.Error.normalize()
+	}
+	return r.Gradle.normalize()
+}
+
+// Kotlin parser instance
+var kotlinParser = participle.MustBuild[KotlinParseResult](commonParserOptions...)
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- Android Logcat ---
+// Example 1: E/AndroidRuntime( 1234): FATAL EXCEPTION: main
+// Example 2: E/AndroidRuntime: java.lang.RuntimeException: Unable to start activity
+// Example 3: 03-17 16:13:47.123  1234  1234 E AndroidRuntime: 	at com.example.app.MainActivity.onCreate(MainActivity.java:42)
+// Example 4: 03-17 16:13:47.123 E/AndroidRuntime( 1234): Process: com.example.app, PID: 1234
+// adb logcat prefixes every line with its priority, tag and usually the process ID, in
+// the brief format (Examples 1 and 2), threadtime, the default (Example 3), or time
+// (Example 4). The Assembler strips the prefix and parses the message as Java output,
+// see Assembler.feedLogcat.
+type LogcatEntry struct {
+	Priority string // V, D, I, W, E, F or A
+	Tag      string // e.g. AndroidRuntime or System.err
+	PID      int    // Zero if not printed
+	Message  string // Text after the prefix, leading whitespace included
+}
+
+var (
+	// logcatDate matches the date and time the threadtime and time formats start with.
+	logcatDate = `(?:\d{4}-)?\d\d-\d\d\s+\d\d:\d\d:\d\d\.\d+\s+`
+	// logcatBrief matches the brief and time formats: E/Tag( 1234): message
+	logcatBrief = regexp.MustCompile(`^(?:` + logcatDate + `)?([VDIWEFA])/([^(:]*?)\s*(?:\(\s*(\d+)\))?: ?(.*)$`)
+	// logcatThreadtime matches the threadtime format: date time pid tid E Tag: message
+	logcatThreadtime = regexp.MustCompile(`^` + logcatDate + `(\d+)\s+\d+\s+([VDIWEFA])\s+(.*?)\s*: ?(.*)$`)
+	// logcatProcess matches the line naming the crashed app after FATAL EXCEPTION.
+	logcatProcess = regexp.MustCompile(`^Process: ([\w.]+)(?::\w+)?, PID: \d+`)
+)
+
+// errNotLogcat rejects lines without a logcat prefix.
+var errNotLogcat = errors.New("not a logcat line")
+
+// parseLogcatLine splits the prefix off a line of adb logcat output.
+func parseLogcatLine(line string) (*LogcatEntry, error) {
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	if m := logcatBrief.FindStringSubmatch(line); m != nil {
+		pid, _ := strconv.Atoi(m[3])
+		return &LogcatEntry{Priority: m[1], Tag: m[2], PID: pid, Message: m[4]}, nil
+	}
+	if m := logcatThreadtime.FindStringSubmatch(line); m != nil {
+		pid, _ := strconv.Atoi(m[1])
+		return &LogcatEntry{Priority: m[2], Tag: m[3], PID: pid, Message: m[4]}, nil
+	}
+	return nil, errNotLogcat
+}
+
+// fatalThread returns the thread named by the "FATAL EXCEPTION: main" line the
+// Android runtime logs before the exception that crashed the app.
+func (e *LogcatEntry) fatalThread() (string, bool) {
+	thread, ok := strings.CutPrefix(strings.TrimSpace(e.Message), "FATAL EXCEPTION: ")
+	return thread, ok
+}
+
+// androidPlatformPackages are the packages of the Android platform and the libraries
+// shipped with every app, whose frames don't locate a crash in the app's own code.
+var androidPlatformPackages = []string{
+	"android.", "androidx.", "com.android.", "com.google.android.", "dalvik.",
+	"java.", "javax.", "kotlin.", "kotlinx.", "libcore.", "sun.",
+}
+
+// isAndroidPlatform reports whether method belongs to androidPlatformPackages.
+func isAndroidPlatform(method string) bool {
+	for _, pkg := range androidPlatformPackages {
+		if strings.HasPrefix(method, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// appFrame returns the frame that locates a crash in the app's own code: the first
+// frame with a location in app, the package of the crashed process, or else outside
+// androidPlatformPackages. The exception's frames are searched before those of its
+// causes, as Android often wraps the app's exception, e.g. in "Unable to start activity".
+func (e *JavaException) appFrame(app string) (JavaFrame, bool) {
+	if app != "" {
+		if f, ok := e.firstFrame(func(method string) bool { return strings.HasPrefix(method, app+".") }); ok {
+			return f, true
+		}
+	}
+	return e.firstFrame(func(method string) bool { return !isAndroidPlatform(method) })
+}
+
+// firstFrame returns the first frame with a location whose method satisfies match,
+// searching the exception's frames and then those of its causes.
+func (e *JavaException) firstFrame(match func(method string) bool) (JavaFrame, bool) {
+	stacks := [][]JavaFrame{e.Frames}
+	for _, cause := range e.Causes {
+		stacks = append(stacks, cause.Frames)
+	}
+	for _, frames := range stacks {
+		for _, f := range frames {
+			if f.File != "" && match(strings.TrimSpace(f.Method)) {
+				return f, true
+			}
+		}
+	}
+	return JavaFrame{}, false
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- Loose Matching ---
+// LooseMatch is a last resort for lines no grammar understands: it extracts the first
+// "path:line[:col]" occurrence from anywhere in the line, e.g. from
+// "[build] ERROR in src/app.ts:10:5 unexpected token".
+
+// loosePattern matches a file name with an extension followed by a line and optional column.
+// Requiring the extension keeps times like 12:30:45 from matching.
+var loosePattern = regexp.MustCompile(`((?:[a-zA-Z]:)?[\w.\-\\/]*\w\.[a-zA-Z]\w*):(\d+)(?::(\d+))?`)
+
+// LooseMatch extracts the first file location in line into a minimal ErrorInfo with
+// Type "Unknown", which has severity warning. The text after the location, or before it if nothing follows,
+// becomes the message. It reports false if the line contains no location.
+func LooseMatch(line string) (ErrorInfo, bool) {
+	for _, m := range loosePattern.FindAllStringSubmatchIndex(line, -1) {
+		if m[0] > 0 && isLooseWordByte(line[m[0]-1]) {
+			continue // Host and port of a URL, e.g. https://example.com:8080
+		}
+		info := ErrorInfo{
+			Filename: line[m[2]:m[3]],
+			Type:     "Unknown",
+			Raw:      line,
+			ParsePos: parsePos(offsetPos(line, m[0])),
+		}
+		info.Line, _ = strconv.Atoi(line[m[4]:m[5]])
+		if m[6] >= 0 {
+			col, _ := strconv.Atoi(line[m[6]:m[7]])
+			info.Column = &col
+		}
+		info.Message = strings.TrimSpace(strings.TrimLeft(line[m[1]:], " :-"))
+		if info.Message == "" {
+			info.Message = strings.TrimSpace(line[:m[0]])
+		}
+		return info, true
+	}
+	return ErrorInfo{}, false
+}
+
+// isLooseWordByte reports whether a location preceded by b starts inside a longer token,
+// such as the "s:" of "https:" taken for a drive letter.
+func isLooseWordByte(b byte) bool {
+	return b == ':' || b == '/' || b == '_' || b >= '0' && b <= '9' || b|0x20 >= 'a' && b|0x20 <= 'z'
+}
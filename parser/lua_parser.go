@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Lua Grammar ---
+// Example:
+//
+//	lua: script.lua:10: attempt to index a nil value (global 'config')
+//	stack traceback:
+//	        [C]: in ?
+//	        script.lua:10: in local 'load'
+//	        script.lua:14: in main chunk
+//	        [C]: in ?
+//
+// The stand-alone interpreter (lua, lua5.4, luajit or its full path) prefixes the error
+// with its own name; Lua locations carry no column. The Assembler attaches the frames
+// of the traceback that follows.
+
+// LuaError is the "lua: file:line: message" line of an uncaught Lua error.
+type LuaError struct {
+	Interpreter string `parser:"@(Word | Path) ':'"`
+	Filename    string `parser:"@Path"`
+	Line        int    `parser:"':' @Number"`
+	Message     Rest   `parser:"':' @@"`
+
+	Frames []LuaFrame // Filled in by the Assembler from the traceback, innermost call first
+
+	Pos lexer.Position
+}
+
+// errNotLuaInterpreter rejects "name: file:line: message" lines not printed by a Lua interpreter.
+var errNotLuaInterpreter = errors.New("not a Lua interpreter prefix")
+
+// normalize accepts lua, luajit and versioned names like lua5.4, also as full paths.
+func (e *LuaError) normalize() error {
+	if !strings.HasPrefix(path.Base(strings.ReplaceAll(e.Interpreter, `\`, "/")), "lua") {
+		return errNotLuaInterpreter
+	}
+	return nil
+}
+
+// LuaTraceback is the "stack traceback:" line that starts the frames of a Lua error.
+type LuaTraceback struct {
+	Header bool `parser:"@('stack' 'traceback' ':')"`
+}
+
+// LuaFrame is one line of a Lua stack traceback. Functions implemented in C are
+// printed as "[C]" and have no location; LuaJIT adds their address ("[C]: at 0x...").
+type LuaFrame struct {
+	Filename string `parser:"( @Path | LBracket 'C' RBracket )"`
+	Line     int    `parser:"( ':' @Number )?"`
+	Function Rest   `parser:"( ':' ('in' | 'at') @@ )?"` // e.g. main chunk, local 'load', function <script.lua:3>
+
+	Pos lexer.Position
+}
+
+// luaFunctionName matches the quoted name of a frame such as "local 'load'" or "function 'M.run'".
+var luaFunctionName = regexp.MustCompile(`^\w+ '(.+)'$`)
+
+// name returns the function of the frame, without the kind of name Lua prints before it.
+func (f LuaFrame) name() string {
+	text := strings.TrimSpace(string(f.Function))
+	if m := luaFunctionName.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	return text
+}
+
+// isLuaTailCalls reports whether line is the "(...tail calls...)" marker Lua prints
+// in a traceback for frames dropped by tail calls.
+func isLuaTailCalls(line string) bool {
+	return strings.TrimSpace(line) == "(...tail calls...)"
+}
+
+// ToErrorInfo converts a LuaError and its frames into the common ErrorInfo format.
+// C functions, which have no location, are left out of the frames.
+func (e *LuaError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Type:     "Error", // Lua doesn't label its errors
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	// Lua prints the innermost call first; Frames lists the outermost call first
+	for i := len(e.Frames) - 1; i >= 0; i-- {
+		if f := e.Frames[i]; f.Filename != "" {
+			info.Frames = append(info.Frames, Frame{Filename: f.Filename, Line: f.Line, Function: f.name()})
+		}
+	}
+	return info
+}
+
+// --- Lua Specific Grammar ---
+// LuaParseResult holds the result of parsing a single line of Lua output: an error,
+// the start of its traceback or a traceback frame.
+type LuaParseResult struct {
+	Error     *LuaError     `parser:"( @@ EOL?"`
+	Traceback *LuaTraceback `parser:"| @@ EOL?"`
+	Frame     *LuaFrame     `parser:"| @@ EOL? )"`
+}
+
+// normalize checks the interpreter prefix of error lines, see LuaError.normalize.
+func (r *LuaParseResult) normalize() error {
+	if r.Error != nil {
+		return r.Error.normalize()
+	}
+	return nil
+}
+
+// Lua parser instance
+// Errors and frames both start with a path, so the parser needs lookahead to backtrack.
+var luaParser = participle.MustBuild[LuaParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Make Grammar (GNU make) ---
+// Make wraps the output of the tools it runs, so its lines are recognized whatever the
+// selected language is, see Assembler. The lines in between are left to that language.
+//
+// Example 1: make[1]: *** [Makefile:10: all] Error 1
+// Example 2: make: *** [build/rules.mk:42: obj/main.o] Error 2
+// Example 3: make: *** [all] Error 2
+// Example 4: make: *** No rule to make target 'install'.  Stop.
+// Make before 4.0 names only the target of a failed recipe, without its location.
+type MakeFailure struct {
+	Makefile string `parser:"('make' | 'gmake') ( LBracket Number RBracket )? ':' '*' '*' '*' ( LBracket ( @(Path | Word) ':'"`
+	Line     int    `parser:"  @Number ':' )?"`
+	Target   string `parser:"  @(~RBracket)+ RBracket )?"`
+	Message  Rest   `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a parsed MakeFailure into the common ErrorInfo format.
+func (e *MakeFailure) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Makefile,
+		Line:     e.Line,
+		Type:     "MakeError",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if e.Target != "" {
+		info.Message = e.Target + ": " + info.Message // e.g. "all: Error 1"
+	}
+	return info
+}
+
+// Example: Makefile:5: *** missing separator.  Stop.
+// Printed for an error in the makefile itself, before anything is built.
+type MakefileError struct {
+	Makefile string `parser:"@(Path | Word) ':'"`
+	Line     int    `parser:"@Number ':' '*' '*' '*'"`
+	Message  Rest   `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a parsed MakefileError into the common ErrorInfo format.
+func (e *MakefileError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Makefile,
+		Line:     e.Line,
+		Type:     "MakeError",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// Example 1: make[1]: Entering directory '/home/dev/project/lib'
+// Example 2: make: *** Waiting for unfinished jobs....
+// Recursive make announces each sub-make, and a parallel build waits for the other
+// jobs after a failure. These lines are recognized but report nothing.
+type MakeNotice struct {
+	Action  string `parser:"('make' | 'gmake') ( LBracket Number RBracket )? ':' ( @('Entering' | 'Leaving') 'directory'"`
+	Waiting bool   `parser:"| @('*' '*' '*' 'Waiting') )"`
+	Detail  Rest   `parser:"@@"` // The directory, or "for unfinished jobs...."
+}
+
+// --- Make Specific Grammar ---
+// MakeParseResult holds the result of parsing a line printed by make itself.
+type MakeParseResult struct {
+	Notice   *MakeNotice    `parser:"( @@ EOL?"`
+	Failure  *MakeFailure   `parser:"| @@ EOL?"`
+	Makefile *MakefileError `parser:"| @@ EOL? )"`
+}
+
+// parseMakeLine parses line if make printed it. Lines that can't be make's are
+// rejected without running the parser, as it is tried on every input line.
+func parseMakeLine(line string) (*MakeParseResult, bool) {
+	if !strings.HasPrefix(line, "make") && !strings.HasPrefix(line, "gmake") && !strings.Contains(line, ": *** ") {
+		return nil, false
+	}
+	result, err := makeParser.ParseString("", line)
+	return result, err == nil
+}
+
+// Make parser instance
+var makeParser = participle.MustBuild[MakeParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
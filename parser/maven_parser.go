@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Maven Grammar (maven-compiler-plugin) ---
+// Example 1: [ERROR] /src/main/java/com/example/Foo.java:[10,5] cannot find symbol
+// Example 2: [WARNING] /src/main/java/com/example/Bar.java:[3,8] unchecked conversion
+// Example 3: [ERROR] C:\src\main\java\com\example\Foo.java:[42] ';' expected
+// Maven reports javac diagnostics with the location in brackets after the file.
+// Other [ERROR] lines (the summary block, "symbol:" details) carry no location and are left unmatched.
+type MavenError struct {
+	Level    string `parser:"LBracket @('ERROR' | 'WARNING') RBracket"`
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' LBracket @Number"`
+	Column   *int   `parser:"( ',' @Number )? RBracket"`
+	Message  Rest   `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a parsed MavenError into the common ErrorInfo format.
+func (e *MavenError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     capitalize(e.Level), // "ERROR" -> "Error", "WARNING" -> "Warning"
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// Maven parser instance
+var mavenParser = participle.MustBuild[MavenError](commonParserOptions...)
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"cmp"
+	"slices"
+)
+
+// --- Merging ---
+
+// MergedWith combines two partial reports of the same error, such as a location-only
+// ErrorInfo and a message-only one, into one. The Assembler uses it to add the location
+// line that follows a message, e.g. Rust's "-->". The receiver takes precedence: each of
+// its empty fields is filled from other, and fields that are set in both keep the
+// receiver's value, with these exceptions:
+//
+//   - The location (Filename, Line, Column, EndLine, EndColumn, Module and ModuleVersion)
+//     is taken from other as a whole if the receiver has neither a filename nor a line.
+//     If both name the same file and line, the receiver's missing column, end and module
+//     are filled from other; otherwise other's location is ignored, so the result never
+//     mixes two locations.
+//   - A Type of "Unknown", as LooseMatch reports, yields to a Type other has.
+//   - Message is the receiver's Message followed by other's, joined by "\n", unless
+//     they are the same, as each report holds a part of the message.
+//   - Frames and OmittedFrames are taken together, from other if the receiver has no frames.
+//   - Raw is the receiver's Raw followed by other's, joined by "\n", as the merged error
+//     was parsed from the lines of both. Notes are concatenated in the same order.
+//
+// Neither value is modified, and the result shares no slices with them.
+func (e ErrorInfo) MergedWith(other ErrorInfo) ErrorInfo {
+	merged := e
+	switch {
+	case e.Filename == "" && e.Line == 0:
+		merged.Filename, merged.Line = other.Filename, other.Line
+		merged.Column, merged.EndLine, merged.EndColumn = other.Column, other.EndLine, other.EndColumn
+		merged.Module, merged.ModuleVersion = other.Module, other.ModuleVersion
+	case e.Filename == other.Filename && e.Line == other.Line:
+		merged.Column = cmp.Or(e.Column, other.Column)
+		merged.Module, merged.ModuleVersion = cmp.Or(e.Module, other.Module), cmp.Or(e.ModuleVersion, other.ModuleVersion)
+		if e.EndLine == nil && e.EndColumn == nil {
+			merged.EndLine, merged.EndColumn = other.EndLine, other.EndColumn
+		}
+	}
+
+	if (e.Type == "" || e.Type == "Unknown") && other.Type != "" {
+		merged.Type = other.Type
+	}
+	merged.Code = cmp.Or(e.Code, other.Code)
+	switch {
+	case e.Message == "":
+		merged.Message = other.Message
+	case other.Message != "" && other.Message != e.Message:
+		merged.Message = e.Message + "\n" + other.Message
+	}
+	switch {
+	case e.Raw == "":
+		merged.Raw = other.Raw
+	case other.Raw != "":
+		merged.Raw = e.Raw + "\n" + other.Raw
+	}
+
+	merged.Frames = slices.Clone(e.Frames)
+	if len(e.Frames) == 0 {
+		merged.Frames, merged.OmittedFrames = slices.Clone(other.Frames), other.OmittedFrames
+	}
+	merged.Notes = slices.Concat(e.Notes, other.Notes)
+
+	merged.Package = cmp.Or(e.Package, other.Package)
+	merged.Goroutine = cmp.Or(e.Goroutine, other.Goroutine)
+	merged.Thread = cmp.Or(e.Thread, other.Thread)
+	merged.ParsePos = cmp.Or(e.ParsePos, other.ParsePos)
+	merged.InputLine = cmp.Or(e.InputLine, other.InputLine)
+	merged.DocURL = cmp.Or(e.DocURL, other.DocURL)
+	merged.DetectedLang = cmp.Or(e.DetectedLang, other.DetectedLang)
+	return merged
+}
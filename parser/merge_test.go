@@ -0,0 +1,124 @@
+package parser_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+func ptr(n int) *int { return &n }
+
+func TestMergedWith(t *testing.T) {
+	frames := []parser.Frame{{Filename: "main.go", Line: 9, Function: "main.main"}}
+	otherFrames := []parser.Frame{{Filename: "util.go", Line: 3, Function: "main.helper"}}
+	tests := []struct {
+		name     string
+		e, other parser.ErrorInfo
+		want     parser.ErrorInfo
+	}{
+		{
+			name:  "location taken as a whole without one",
+			e:     parser.ErrorInfo{Type: "Error", Message: "cannot find value"},
+			other: parser.ErrorInfo{Filename: "src/main.rs", Line: 4, Column: ptr(5), EndLine: ptr(4), EndColumn: ptr(11), Module: "app", ModuleVersion: "v1.0.0"},
+			want:  parser.ErrorInfo{Filename: "src/main.rs", Line: 4, Column: ptr(5), EndLine: ptr(4), EndColumn: ptr(11), Module: "app", ModuleVersion: "v1.0.0", Type: "Error", Message: "cannot find value"},
+		},
+		{
+			name:  "same location fills column and end",
+			e:     parser.ErrorInfo{Filename: "main.go", Line: 3},
+			other: parser.ErrorInfo{Filename: "main.go", Line: 3, Column: ptr(7), EndLine: ptr(3), EndColumn: ptr(9)},
+			want:  parser.ErrorInfo{Filename: "main.go", Line: 3, Column: ptr(7), EndLine: ptr(3), EndColumn: ptr(9)},
+		},
+		{
+			name:  "other location ignored",
+			e:     parser.ErrorInfo{Filename: "main.go", Line: 3},
+			other: parser.ErrorInfo{Filename: "util.go", Line: 8, Column: ptr(2), Module: "app"},
+			want:  parser.ErrorInfo{Filename: "main.go", Line: 3},
+		},
+		{
+			name:  "line without filename kept",
+			e:     parser.ErrorInfo{Line: 3},
+			other: parser.ErrorInfo{Filename: "main.go", Line: 8},
+			want:  parser.ErrorInfo{Line: 3},
+		},
+		{
+			name:  "type filled",
+			e:     parser.ErrorInfo{},
+			other: parser.ErrorInfo{Type: "Warning"},
+			want:  parser.ErrorInfo{Type: "Warning"},
+		},
+		{
+			name:  "unknown type yields",
+			e:     parser.ErrorInfo{Type: "Unknown"},
+			other: parser.ErrorInfo{Type: "SyntaxError"},
+			want:  parser.ErrorInfo{Type: "SyntaxError"},
+		},
+		{
+			name:  "unknown type kept without another",
+			e:     parser.ErrorInfo{Type: "Unknown"},
+			other: parser.ErrorInfo{},
+			want:  parser.ErrorInfo{Type: "Unknown"},
+		},
+		{
+			name:  "specific type kept",
+			e:     parser.ErrorInfo{Type: "Warning", Code: "E0425"},
+			other: parser.ErrorInfo{Type: "Error", Code: "E0308"},
+			want:  parser.ErrorInfo{Type: "Warning", Code: "E0425"},
+		},
+		{
+			name:  "message filled",
+			e:     parser.ErrorInfo{},
+			other: parser.ErrorInfo{Message: "division by zero"},
+			want:  parser.ErrorInfo{Message: "division by zero"},
+		},
+		{
+			name:  "messages concatenated",
+			e:     parser.ErrorInfo{Message: "not enough arguments in call to greet"},
+			other: parser.ErrorInfo{Message: "have (string)"},
+			want:  parser.ErrorInfo{Message: "not enough arguments in call to greet\nhave (string)"},
+		},
+		{
+			name:  "same message once",
+			e:     parser.ErrorInfo{Message: "boom"},
+			other: parser.ErrorInfo{Message: "boom"},
+			want:  parser.ErrorInfo{Message: "boom"},
+		},
+		{
+			name:  "frames taken without any",
+			e:     parser.ErrorInfo{},
+			other: parser.ErrorInfo{Frames: otherFrames, OmittedFrames: 2},
+			want:  parser.ErrorInfo{Frames: otherFrames, OmittedFrames: 2},
+		},
+		{
+			name:  "frames kept",
+			e:     parser.ErrorInfo{Frames: frames},
+			other: parser.ErrorInfo{Frames: otherFrames, OmittedFrames: 2},
+			want:  parser.ErrorInfo{Frames: frames},
+		},
+		{
+			name:  "raw and notes concatenated",
+			e:     parser.ErrorInfo{Raw: "error[E0425]: cannot find value", Notes: []string{"a"}},
+			other: parser.ErrorInfo{Raw: " --> src/main.rs:4:5", Notes: []string{"b"}},
+			want:  parser.ErrorInfo{Raw: "error[E0425]: cannot find value\n --> src/main.rs:4:5", Notes: []string{"a", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.e.MergedWith(tt.other)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergedWithSharesNoSlices(t *testing.T) {
+	e := parser.ErrorInfo{Notes: []string{"a"}}
+	other := parser.ErrorInfo{Frames: []parser.Frame{{Filename: "main.go", Line: 9}}, Notes: []string{"b"}}
+	merged := e.MergedWith(other)
+	merged.Frames[0].Line = 1
+	merged.Notes[0] = "changed"
+	if other.Frames[0].Line != 9 || e.Notes[0] != "a" {
+		t.Errorf("merging modified its inputs: %+v, %+v", e, other)
+	}
+}
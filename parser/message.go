@@ -0,0 +1,45 @@
+package parser
+
+import "strings"
+
+// --- Message Normalization ---
+
+// NormalizeMessage collapses every run of whitespace in msg, including tabs and
+// newlines, into a single space and trims both ends, e.g. for aligned table output.
+func NormalizeMessage(msg string) string {
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// untrimMessage restores the whitespace that ToErrorInfo trimmed from around the
+// message of info, including a trailing "\r", by finding where the message ends in
+// its raw input lines. Messages built from several pieces (e.g. a Go test name and
+// its assertion) only get the whitespace around the part taken from the input.
+// The message is returned unchanged if it doesn't end any raw line.
+func untrimMessage(info ErrorInfo) string {
+	if info.Message == "" {
+		return info.Message
+	}
+	lines := strings.Split(info.Raw, "\n")
+	for _, whole := range []bool{true, false} { // Prefer a line holding the whole message
+		for _, line := range lines {
+			body := strings.TrimRight(line, " \t\r")
+			for p := 0; p < len(body); p++ {
+				if p > 0 && !isMessageSpace(body[p-1]) || isMessageSpace(body[p]) {
+					continue
+				}
+				tail := body[p:]
+				if whole && tail != info.Message || !strings.HasSuffix(info.Message, tail) {
+					continue
+				}
+				start := len(strings.TrimRight(line[:p], " \t"))
+				return info.Message[:len(info.Message)-len(tail)] + line[start:]
+			}
+		}
+	}
+	return info.Message
+}
+
+// isMessageSpace reports whether c is whitespace that ToErrorInfo trims.
+func isMessageSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
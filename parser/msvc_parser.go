@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- MSVC Grammar (cl.exe, link.exe and MSBuild) ---
+// Example 1: main.cpp(10): error C2065: 'foo': undeclared identifier
+// Example 2: C:\src\app\util.cpp(42,17): warning C4996: 'strcpy': This function may be unsafe. [C:\src\app\app.vcxproj]
+// Example 3: main.obj : error LNK2019: unresolved external symbol "void __cdecl bar(void)" referenced in function main
+// Example 4: LINK : fatal error LNK1120: 1 unresolved externals
+// Example 5: main.cpp(5): note: see declaration of 'foo'
+// The location uses the same parenthesized form as C#; linker errors name the object
+// file or LINK itself instead of a source location.
+type MSVCError struct {
+	Filename string         `parser:"@(Path | Word)"`
+	Location *ParenLocation `parser:"@@?"`
+	Fatal    bool           `parser:"':' @'fatal'?"`
+	Severity string         `parser:"@('error' | 'warning' | 'note')"`
+	Code     string         `parser:"@Word?"` // e.g. C2065, C4996, LNK2019
+	Message  Rest           `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// msvcCode matches compiler (C), linker (LNK) and MSBuild (MSB) diagnostic codes.
+var msvcCode = regexp.MustCompile(`^(C|LNK|MSB)\d{4}$`)
+
+// errNotMSVC rejects lines that only share MSVC's shape, e.g. C# diagnostics or "foo: error: ...".
+var errNotMSVC = errors.New("not an MSVC diagnostic")
+
+// normalize validates the code and drops the trailing " [project.vcxproj]" MSBuild appends.
+func (e *MSVCError) normalize() error {
+	if e.Code != "" && !msvcCode.MatchString(e.Code) {
+		return errNotMSVC
+	}
+	if e.Location == nil && e.Code == "" {
+		return errNotMSVC // Only notes go without a code, and they always have a location
+	}
+	e.Message = Rest(stripProject(string(e.Message)))
+	return nil
+}
+
+// ToErrorInfo converts a parsed MSVCError into the common ErrorInfo format.
+func (e *MSVCError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Type:     capitalize(e.Severity), // "fatal error" is reported as a plain Error
+		Code:     e.Code,
+		Message:  string(e.Message),
+	}
+	if info.Filename == "LINK" {
+		info.Filename = "" // The linker itself, not a file
+	}
+	if e.Location != nil {
+		info.Line = e.Location.Line
+		info.Column = e.Location.Column
+		info.EndLine = e.Location.EndLine
+		info.EndColumn = e.Location.EndColumn
+	}
+	return info
+}
+
+// MSVC parser instance
+var msvcParser = participle.MustBuild[MSVCError](commonParserOptions...)
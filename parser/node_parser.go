@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Node.js Grammar ---
+// Example 1: ReferenceError: foo is not defined
+// Example 2:     at Object.<anonymous> (/app/index.js:3:1)
+// Example 3:     at node:internal/main/run_main_module:23:47
+// Example 4: AssertionError [ERR_ASSERTION]: Expected values to be strictly equal
+// Like Java, the header names the error and the `at` frames follow on their own lines;
+// the Assembler attaches them.
+
+// NodeError captures the error class, optional error code and message of a stack trace header.
+type NodeError struct {
+	Class   string `parser:"@Word"`                        // e.g. TypeError
+	Code    string `parser:"( LBracket @Word RBracket )?"` // e.g. ERR_ASSERTION
+	Message Rest   `parser:"':' @@"`
+
+	Frames []NodeFrame // Filled in by the Assembler from the following `at` lines
+
+	Pos lexer.Position
+}
+
+// NodeFrame is one `at` line of a Node.js stack trace. Its text is split after parsing
+// because both the function and the location are optional:
+// `at func (file:line:col)`, `at file:line:col` or `at new Promise (<anonymous>)`.
+type NodeFrame struct {
+	Text Rest `parser:"'at' @@"`
+
+	Function string
+	File     string // Empty for frames without a location
+	Line     int
+	Column   int
+
+	Pos lexer.Position
+}
+
+// nodeErrorClass matches the class names of JavaScript errors, e.g. Error, TypeError or DOMException.
+var nodeErrorClass = regexp.MustCompile(`^(?:[A-Z]\w*)?(?:Error|Exception)$`)
+
+// nodeLocation matches the file:line:col location of a frame.
+var nodeLocation = regexp.MustCompile(`^(.+):(\d+):(\d+)$`)
+
+// errNotNodeError rejects header lines whose class is not a JavaScript error class.
+var errNotNodeError = errors.New("not a JavaScript error class")
+
+// split separates the function name from the location of the frame.
+func (f *NodeFrame) split() {
+	text := strings.TrimSpace(string(f.Text))
+	loc := text
+	if open := strings.LastIndex(text, " ("); open >= 0 && strings.HasSuffix(text, ")") {
+		f.Function = text[:open]
+		loc = text[open+2 : len(text)-1]
+	}
+	m := nodeLocation.FindStringSubmatch(loc)
+	if m == nil {
+		if f.Function == "" {
+			f.Function = text // Neither parenthesized nor located, e.g. "at native"
+		}
+		return
+	}
+	f.File = m[1]
+	f.Line, _ = strconv.Atoi(m[2])
+	f.Column, _ = strconv.Atoi(m[3])
+}
+
+// isInternal reports whether the frame belongs to Node.js itself rather than the application.
+func (f NodeFrame) isInternal() bool {
+	return strings.HasPrefix(f.File, "node:") || strings.HasPrefix(f.File, "internal/")
+}
+
+// ToErrorInfo converts a NodeError and its frames into the common ErrorInfo format.
+// The first application frame, skipping Node.js internals, becomes the error location.
+func (e *NodeError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     e.Class,
+		Code:     e.Code,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	for _, frame := range e.Frames {
+		if frame.File == "" {
+			continue
+		}
+		info.Frames = append(info.Frames, Frame{Filename: frame.File, Line: frame.Line, Function: frame.Function})
+		if info.Filename == "" && !frame.isInternal() {
+			col := frame.Column
+			info.Filename = frame.File
+			info.Line = frame.Line
+			info.Column = &col
+		}
+	}
+	// Node prints the innermost call first; Frames lists the outermost call first
+	for i, j := 0, len(info.Frames)-1; i < j; i, j = i+1, j-1 {
+		info.Frames[i], info.Frames[j] = info.Frames[j], info.Frames[i]
+	}
+	return info
+}
+
+// --- Node.js Specific Grammar ---
+// NodeParseResult holds the result of parsing a single line of Node.js output.
+type NodeParseResult struct {
+	Frame *NodeFrame `parser:"( @@ EOL?"`
+	Error *NodeError `parser:"| @@ EOL? )"`
+}
+
+// normalize splits frame lines and rejects headers that are not JavaScript errors.
+func (r *NodeParseResult) normalize() error {
+	if r.Frame != nil {
+		r.Frame.split()
+	}
+	if r.Error != nil && !nodeErrorClass.MatchString(r.Error.Class) {
+		return errNotNodeError
+	}
+	return nil
+}
+
+// Node.js parser instance
+var nodeParser = participle.MustBuild[NodeParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
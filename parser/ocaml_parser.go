@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- OCaml Grammar (ocamlc, ocamlopt, dune) ---
+// Like a Python traceback, the location comes on its own line before the message:
+//
+//	File "src/main.ml", line 10, characters 5-12:
+//	10 |   let x = foo 1
+//	             ^^^^^^^
+//	Error: Unbound value foo
+//
+// Example 1: File "src/main.ml", lines 3-5, characters 2-10:
+// Example 2: Warning 26 [unused-var]: unused variable x.
+// Example 3: Error (warning 32 [unused-value-declaration]): unused value bar.
+// Example 4: Alert deprecated: module Stdlib.Pervasives
+// Message lines may wrap onto indented continuation lines, which the Assembler appends.
+
+// OCamlLocation is the `File "...", line N, characters A-B:` header of a diagnostic.
+// Character offsets are 0-based and the end is exclusive; with a line range the end
+// offset belongs to the last line.
+type OCamlLocation struct {
+	Filename string `parser:"FileStart @(Path | Word) \"\\\"\""`
+	Line     int    `parser:"',' ('line' | 'lines') @Number"`
+	EndLine  *int   `parser:"('-' @Number)?"`
+	Start    *int   `parser:"(',' 'characters' @Number"`
+	End      *int   `parser:" '-' @Number)? ':'"`
+
+	Pos lexer.Position
+}
+
+// OCamlMessage is the "Error: ..." / "Warning N [name]: ..." line following an OCamlLocation.
+type OCamlMessage struct {
+	Severity string `parser:"@('Error' | 'Warning' | 'Alert')"`
+	Text     Rest   `parser:"@@"` // e.g. " 26 [unused-var]: unused variable x."
+
+	Code    string // Warning number, or alert name
+	Message string
+
+	Pos lexer.Position
+}
+
+// ocamlMessageText splits the text after the severity into a warning number
+// ("26", or "(warning 32 [...])" for a warning turned into an error), an alert name,
+// an optional bracketed warning name and the message.
+var ocamlMessageText = regexp.MustCompile(`^\s*(?:(\d+)|\(warning (\d+)[^)]*\)|([\w-]+))?\s*(?:\[[\w-]+\])?\s*:\s*(.*)$`)
+
+// errNotOCamlMessage rejects lines that start with a severity word but have no message.
+var errNotOCamlMessage = errors.New("not an ocaml diagnostic message")
+
+// normalize splits the code off the message.
+func (m *OCamlMessage) normalize() error {
+	parts := ocamlMessageText.FindStringSubmatch(string(m.Text))
+	if parts == nil {
+		return errNotOCamlMessage
+	}
+	m.Code = parts[1] + parts[2] + parts[3] // At most one of them is set
+	m.Message = strings.TrimSpace(parts[4])
+	return nil
+}
+
+// ToErrorInfo converts a diagnostic into the common ErrorInfo format. Alerts are
+// reported as warnings; loc may be nil for messages printed without a location.
+func (m *OCamlMessage) ToErrorInfo(loc *OCamlLocation) ErrorInfo {
+	info := ErrorInfo{
+		Type:     m.Severity,
+		Code:     m.Code,
+		Message:  m.Message,
+		ParsePos: parsePos(m.Pos),
+	}
+	if info.Type == "Alert" {
+		info.Type = "Warning"
+	}
+	if loc == nil {
+		return info
+	}
+	info.ParsePos = parsePos(loc.Pos) // The diagnostic starts at its location line
+	info.Filename, info.Line, info.EndLine = loc.Filename, loc.Line, loc.EndLine
+	if loc.Start != nil {
+		col := *loc.Start + 1 // 0-based offset to 1-based column
+		info.Column = &col
+		if loc.End != nil && (*loc.End > col || loc.EndLine != nil) {
+			end := *loc.End // Exclusive 0-based end is the inclusive 1-based one
+			info.EndColumn = &end
+			if info.EndLine == nil {
+				line := info.Line
+				info.EndLine = &line
+			}
+		}
+	}
+	return info
+}
+
+// --- OCaml Specific Grammar ---
+// OCamlParseResult holds the result of parsing a single line of OCaml compiler output.
+type OCamlParseResult struct {
+	Location *OCamlLocation `parser:"( @@ EOL?"`
+	Message  *OCamlMessage  `parser:"| @@ EOL? )"`
+}
+
+// normalize post-processes message lines, see OCamlMessage.normalize.
+func (r *OCamlParseResult) normalize() error {
+	if r.Message != nil {
+		return r.Message.normalize()
+	}
+	return nil
+}
+
+// OCaml parser instance
+var ocamlParser = participle.MustBuild[OCamlParseResult](commonParserOptions...)
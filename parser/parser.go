@@ -0,0 +1,449 @@
+// Package parser turns compiler and runtime log output into structured ErrorInfo values.
+//
+// Each supported language has its own grammar. ParseLine parses a single line and returns
+// the language specific result struct (e.g. *CppDiagnostic, *GoParseResult) or *UnmatchedLine;
+// callers type-switch on the result and call its ToErrorInfo method.
+//
+// Concurrency: the grammars are built once at package initialization and never modified
+// afterwards, and every parse gets its own lexer state, so ParseLine, ParseLines, ParseStream
+// and DetectLanguage are safe to call from multiple goroutines, e.g. to fan out a large log
+// split into independent chunks. The custom language registry is guarded by a lock, so
+// RegisterCustomLanguage may run concurrently with parsing. An Assembler holds per-stream
+// state and must not be shared.
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// Language represents the type of log being parsed.
+type Language int
+
+const (
+	LangUnknown Language = iota
+	LangFlutter
+	LangPython
+	LangGo
+	LangRust
+	LangTypeScript
+	LangJava
+	LangCpp
+	LangRuby
+	LangPHP
+	LangKotlin
+	LangCSharp
+	LangNode
+	LangSwift
+	LangScala
+	LangElixir
+	LangHaskell
+	LangMSVC
+	LangMaven
+	LangTerraform
+	LangClojure
+	LangPerl
+	LangWebpack
+	LangBazel
+	LangGoJSON
+	LangOCaml
+	LangLua
+	LangLogcat
+	LangAuto        // Detect the language from the input, see DetectLanguage
+	LangAll         // Try every built-in language on each line, see NewAssembler
+	langCustomStart // Languages added with RegisterCustomLanguage are numbered from here
+)
+
+// String returns the lowercase name of the language as accepted by the -lang flag.
+func (l Language) String() string {
+	switch l {
+	case LangFlutter:
+		return "flutter"
+	case LangPython:
+		return "python"
+	case LangGo:
+		return "go"
+	case LangRust:
+		return "rust"
+	case LangTypeScript:
+		return "typescript"
+	case LangJava:
+		return "java"
+	case LangCpp:
+		return "cpp"
+	case LangRuby:
+		return "ruby"
+	case LangPHP:
+		return "php"
+	case LangKotlin:
+		return "kotlin"
+	case LangCSharp:
+		return "csharp"
+	case LangNode:
+		return "node"
+	case LangSwift:
+		return "swift"
+	case LangScala:
+		return "scala"
+	case LangElixir:
+		return "elixir"
+	case LangHaskell:
+		return "haskell"
+	case LangMSVC:
+		return "msvc"
+	case LangMaven:
+		return "maven"
+	case LangTerraform:
+		return "terraform"
+	case LangClojure:
+		return "clojure"
+	case LangPerl:
+		return "perl"
+	case LangWebpack:
+		return "webpack"
+	case LangBazel:
+		return "bazel"
+	case LangGoJSON:
+		return "gojson"
+	case LangOCaml:
+		return "ocaml"
+	case LangLua:
+		return "lua"
+	case LangLogcat:
+		return "logcat"
+	case LangAuto:
+		return "auto"
+	case LangAll:
+		return "all"
+	default:
+		if c, ok := lookupCustom(l); ok {
+			return c.name
+		}
+		return "unknown"
+	}
+}
+
+// LanguageByName returns the built-in language whose String is name, such as the
+// ErrorInfo.DetectedLang of an error found with LangAll.
+func LanguageByName(name string) (Language, bool) {
+	for l := LangUnknown + 1; l < LangAuto; l++ {
+		if l.String() == name {
+			return l, true
+		}
+	}
+	return LangUnknown, false
+}
+
+// ErrorInfo holds the common structured information extracted from an error message.
+// Use pointers for optional fields like Column.
+// JSON tags define the stable machine-readable schema; absent positions are emitted as null.
+type ErrorInfo struct {
+	Filename  string   `json:"filename"`
+	Line      int      `json:"line"`
+	Column    *int     `json:"column"`              // Optional column
+	EndLine   *int     `json:"endLine"`             // Optional end of the reported span
+	EndColumn *int     `json:"endColumn"`           // Optional end column of the reported span
+	Type      string   `json:"type"`                // Error, Warning, Panic, etc.
+	Code      string   `json:"code"`                // Optional diagnostic code such as E0308, TS2322 or -Wunused-variable
+	Message   string   `json:"message"`             // The actual error message text
+	Raw       string   `json:"raw"`                 // Input line(s) the error was parsed from, joined by "\n"
+	Frames    []Frame  `json:"frames,omitempty"`    // Call chain leading to the error, outermost first
+	Notes     []string `json:"notes,omitempty"`     // Secondary notes and hints, e.g. Rust's "help: ..."
+	Package   string   `json:"package,omitempty"`   // Package being built, e.g. from Go's "# example.com/foo" header
+	Goroutine *int     `json:"goroutine,omitempty"` // Go goroutine that panicked or raced
+	Thread    string   `json:"thread,omitempty"`    // Name of the thread that panicked, e.g. Rust's "main"
+	ParsePos  *LinePos `json:"parsePos,omitempty"`  // Where the grammar started matching in the input line
+	InputLine int      `json:"inputLine,omitempty"` // 1-based input line of the error, see Assembler
+	DocURL    string   `json:"docUrl,omitempty"`    // Documentation of Code, see DocURL; set by the caller
+
+	OmittedFrames int    `json:"omittedFrames,omitempty"` // Frames left out of Frames, see Assembler.SetMaxFrames
+	DetectedLang  string `json:"detectedLang,omitempty"`  // Language whose parser produced the error, set with LangAll
+	Module        string `json:"module,omitempty"`        // Go module split off Filename, see ShortenModulePath; set by the caller
+	ModuleVersion string `json:"moduleVersion,omitempty"` // Version of Module, e.g. v1.2.3
+}
+
+// LinePos is a position within a single input line, for tools that highlight the error
+// in the log itself rather than in the source file. For a multi-line error it refers
+// to the line that starts it, usually the first line of Raw. Positions are in the line
+// as parsed: after -strip-ansi, within the Output text for LangGoJSON and within the
+// message after the prefix for LangLogcat.
+type LinePos struct {
+	Offset int `json:"offset"` // Bytes from the start of the line
+	Column int `json:"column"` // 1-based, counting characters rather than bytes
+}
+
+// parsePos converts the position participle recorded for a grammar struct. Lines are
+// parsed one at a time, so its line number is always 1 and is dropped.
+func parsePos(pos lexer.Position) *LinePos {
+	return &LinePos{Offset: pos.Offset, Column: pos.Column}
+}
+
+// capitalize turns a severity word such as "error" or "WARNING" into the Type form "Error".
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
+
+// offsetPos returns the position of a byte offset in line, for matches made without a grammar.
+func offsetPos(line string, offset int) lexer.Position {
+	pos := lexer.Position{Line: 1, Column: 1}
+	pos.Advance(line[:offset])
+	return pos
+}
+
+// Frame is one entry of a stack trace or traceback.
+type Frame struct {
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	Function string `json:"function,omitempty"`
+}
+
+// --- Custom Lexer ---
+// Define custom lexer rules to handle file paths and specific error keywords.
+var logLexer = lexer.MustSimple([]lexer.SimpleRule{
+	{Name: "Whitespace", Pattern: `[ \t]+`},
+	{Name: "EOL", Pattern: `[\n\r]+`},             // End of line
+	{Name: "PanicStart", Pattern: `panic:`},       // Specific token for Go panics
+	{Name: "TestFailStart", Pattern: `--- FAIL:`}, // Specific token for Go test failures
+	{Name: "FileStart", Pattern: `File "`},        // Specific token for Python File lines
+	{Name: "Arrow", Pattern: `-->`},               // Rust arrow pointing to source location
+	// A file:// URI up to the ':' before its line number, e.g. file:///home/me/Main.kt or
+	// file:///C:/work/Main.kt, as printed by Gradle. Tried before Path, which stops at ':'.
+	{Name: "FileURI", Pattern: `file://(?:/[a-zA-Z]:)?[^\s:]*`},
+	// A Go module path with its version, as printed for dependencies by -trimpath builds:
+	// example.com/foo@v1.2.3/bar.go. Tried before Path, which stops at '@'.
+	{Name: "ModulePath", Pattern: `[\w\-.]+(?:/[\w\-.]+)*@v\d+\.\d+\.\d+[\w.\-+]*/[\w.\-/]*`},
+	// Path needs to handle various characters including '/', '.', '-', '_', and drive letters C: etc.
+	// It must contain at least one separator so plain words and numbers are not swallowed,
+	// and it stops before ':' followed by a number (line number).
+	// Unix (./pkg/main.go), Windows (C:\Users\me\main.go, .\pkg\util.go, \\server\share\x.go)
+	// and mixed (C:/Users/me/main.go) paths all lex as a single token. It is tried before the
+	// code tokens below so that a segment such as E1234\ or TS5\ stays part of the path.
+	{Name: "Path", Pattern: `(?:[a-zA-Z]:)?[\w\-]*[\\/.][\w.\-\\/]*`},
+	{Name: "ErrorCode", Pattern: `E\d{4}\b`},   // Rust error code like E0308
+	{Name: "TSCode", Pattern: `TS\d+\b`},       // TypeScript error code like TS2322
+	{Name: "Offset", Pattern: `\+0x[0-9a-f]+`}, // Go stack frame instruction offset like +0x8d
+	{Name: "Number", Pattern: `\d+`},
+	{Name: "Word", Pattern: `[a-zA-Z_][a-zA-Z0-9_]*`}, // Identifiers, keywords like Error, panic
+	{Name: "String", Pattern: `"(\\"|[^"])*"`},        // Standard string literal for Python filenames
+	{Name: "Colon", Pattern: `:`},
+	{Name: "Comma", Pattern: `,`},
+	{Name: "LBracket", Pattern: `\[`}, // Left square bracket for error code
+	{Name: "RBracket", Pattern: `\]`}, // Right square bracket for error code
+	{Name: "LParen", Pattern: `\(`},   // Left parenthesis of a C#/MSVC (line,col) location
+	{Name: "RParen", Pattern: `\)`},   // Right parenthesis of a C#/MSVC (line,col) location
+	{Name: "Other", Pattern: `.`},     // Catch any other single character
+})
+
+// --- Rest Of Line ---
+// Rest captures the remainder of the current line verbatim, including whitespace
+// that the parsers otherwise elide. It is used for free-form message text.
+type Rest string
+
+var eolToken = logLexer.Symbols()["EOL"]
+
+// Parse implements participle.Parseable by consuming raw tokens up to the end of the line.
+func (r *Rest) Parse(lex *lexer.PeekingLexer) error {
+	var sb strings.Builder
+	for {
+		t, cursor := lex.PeekAny(func(lexer.Token) bool { return true })
+		if t.EOF() || t.Type == eolToken {
+			break
+		}
+		sb.WriteString(t.Value)
+		lex.FastForward(cursor)
+	}
+	*r = Rest(sb.String())
+	return nil
+}
+
+// --- Paths With Spaces ---
+// SpacedPath captures a file path that may contain spaces, such as macOS paths like
+// "/Users/me/My Project/main.swift", which the Path token alone would split.
+// It consumes raw tokens up to the first ':' that is followed by a line number.
+type SpacedPath string
+
+var (
+	colonToken  = logLexer.Symbols()["Colon"]
+	numberToken = logLexer.Symbols()["Number"]
+)
+
+// Parse implements participle.Parseable.
+func (p *SpacedPath) Parse(lex *lexer.PeekingLexer) error {
+	always := func(lexer.Token) bool { return true }
+	var sb strings.Builder
+	for {
+		t, cursor := lex.PeekAny(always)
+		if t.EOF() || t.Type == eolToken {
+			break
+		}
+		if t.Type == colonToken {
+			checkpoint := lex.MakeCheckpoint()
+			lex.FastForward(cursor)
+			next, _ := lex.PeekAny(always)
+			lex.LoadCheckpoint(checkpoint)
+			if next.Type == numberToken {
+				break
+			}
+		}
+		sb.WriteString(t.Value)
+		lex.FastForward(cursor)
+	}
+	path := strings.TrimSpace(sb.String())
+	if path == "" {
+		return participle.NextMatch
+	}
+	*p = SpacedPath(path)
+	return nil
+}
+
+// --- Parenthesized Locations ---
+// ParenLocation is the "(line)", "(line,col)" or "(line,col,endline,endcol)" location
+// that Microsoft tools (MSBuild, cl.exe, csc) put right after the file name.
+type ParenLocation struct {
+	Line      int  `parser:"LParen @Number"`
+	Column    *int `parser:"( ',' @Number"`
+	EndLine   *int `parser:"  ( ',' @Number"`
+	EndColumn *int `parser:"    ',' @Number )? )? RParen"`
+}
+
+// stripProject drops the trailing " [project.vcxproj]" MSBuild appends to diagnostics.
+func stripProject(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if start := strings.LastIndex(msg, " ["); start >= 0 && strings.HasSuffix(msg, "proj]") {
+		msg = msg[:start]
+	}
+	return msg
+}
+
+// --- Unmatched Line ---
+// Represents a line that did not match the expected grammar for the selected language.
+type UnmatchedLine struct {
+	Content Rest `parser:"@@"`
+}
+
+// --- Parser Setup ---
+
+// Common parser options used across different language parsers
+var commonParserOptions = []participle.Option{
+	participle.Lexer(logLexer),
+	participle.Elide("Whitespace", "EOL"), // Ignore whitespace and line endings between meaningful tokens
+	participle.Map(func(t lexer.Token) (lexer.Token, error) {
+		// This mapping seems intended to ignore EOL unless explicitly matched,
+		// but the current implementation doesn't modify the token.
+		// Keeping it as is for now, assuming it serves a purpose or was WIP.
+		// If EOL should be generally ignored, Elide("EOL") might be simpler.
+		return t, nil
+	}, "EOL"), // Apply mapping to EOL tokens
+}
+
+// normalizer is implemented by parse results that post-process their captures,
+// e.g. splitting a trailing flag off a message. A non-nil error rejects the line.
+type normalizer interface {
+	normalize() error
+}
+
+// ParseLine parses a single line of text based on the provided language context.
+// It returns the specific parsed struct (e.g., *CppDiagnostic), *UnmatchedLine, or an error.
+// It is safe for concurrent use, see the package documentation.
+func ParseLine(line string, lang Language) (result interface{}, err error) {
+	// Grammars end in an optional EOL, so the line is parsed as is; appending the
+	// newline they once required cost an allocation per line. No grammar matches
+	// an empty line, so skip the parsers for the blank lines common in logs.
+	if line == "" || line == "\n" {
+		return unmatchedLine(line), nil
+	}
+
+	switch lang {
+	case LangFlutter:
+		result, err = flutterParser.ParseString("", line)
+	case LangPython:
+		result, err = pythonParser.ParseString("", line)
+	case LangGo:
+		result, err = goParser.ParseString("", line)
+	case LangRust:
+		result, err = rustParser.ParseString("", line)
+	case LangTypeScript:
+		result, err = typeScriptParser.ParseString("", line)
+	case LangJava:
+		result, err = javaParser.ParseString("", line)
+	case LangCpp:
+		result, err = cppParser.ParseString("", line)
+	case LangRuby:
+		result, err = rubyParser.ParseString("", line)
+	case LangPHP:
+		result, err = phpParser.ParseString("", line)
+	case LangKotlin:
+		result, err = kotlinParser.ParseString("", line)
+	case LangCSharp:
+		result, err = csharpParser.ParseString("", line)
+	case LangNode:
+		result, err = nodeParser.ParseString("", line)
+	case LangSwift:
+		result, err = swiftParser.ParseString("", line)
+	case LangScala:
+		result, err = scalaParser.ParseString("", line)
+	case LangElixir:
+		result, err = elixirParser.ParseString("", line)
+	case LangHaskell:
+		result, err = haskellParser.ParseString("", line)
+	case LangMSVC:
+		result, err = msvcParser.ParseString("", line)
+	case LangMaven:
+		result, err = mavenParser.ParseString("", line)
+	case LangTerraform:
+		result, err = terraformParser.ParseString("", line)
+	case LangClojure:
+		result, err = clojureParser.ParseString("", line)
+	case LangPerl:
+		result, err = perlParser.ParseString("", line)
+	case LangWebpack:
+		result, err = webpackParser.ParseString("", line)
+	case LangBazel:
+		result, err = bazelParser.ParseString("", line)
+	case LangGoJSON:
+		result, err = parseGoJSONEvent(line)
+	case LangOCaml:
+		result, err = ocamlParser.ParseString("", line)
+	case LangLua:
+		result, err = luaParser.ParseString("", line)
+	case LangLogcat:
+		result, err = parseLogcatLine(line)
+	default:
+		c, ok := lookupCustom(lang)
+		if !ok {
+			return nil, fmt.Errorf("unknown language specified for parsing")
+		}
+		result, err = c.parse(strings.TrimSuffix(line, "\n"))
+	}
+
+	// Some grammars capture free-form text that needs splitting or validating after parsing
+	if n, ok := result.(normalizer); ok && err == nil {
+		err = n.normalize()
+	}
+
+	// If parsing for the specific language failed, the line is unmatched
+	if err != nil {
+		return unmatchedLine(line), nil
+	}
+
+	return result, nil
+}
+
+// unmatchedLine wraps a line no grammar matched. Like Rest, the content stops at the
+// first line break.
+func unmatchedLine(line string) *UnmatchedLine {
+	if i := strings.IndexAny(line, "\r\n"); i >= 0 {
+		line = line[:i]
+	}
+	return &UnmatchedLine{Content: Rest(line)}
+}
+
+// Note: The GetErrorInfo helper function is removed.
+// Logic for converting parsed structs to ErrorInfo lives in each result's ToErrorInfo method,
+// while context spanning several lines (like Python's multi-line errors) is handled by Assembler.
@@ -0,0 +1,36 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// FuzzParseLine checks that no input makes the parsers panic, whatever the language. Each
+// input is parsed as a line by every grammar, and as the lines it holds by the Assembler,
+// which also turns the results into errors. Seeds are the lines of the fixtures:
+//
+//	go test -fuzz FuzzParseLine ./parser
+func FuzzParseLine(f *testing.F) {
+	seen := map[string]bool{}
+	for _, fx := range readFixtures(f) {
+		for _, line := range fx.input {
+			if !seen[line] {
+				seen[line] = true
+				f.Add(line)
+			}
+		}
+	}
+	langs := builtinLanguages()
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, lang := range langs {
+			if lang < parser.LangAuto {
+				if _, err := parser.ParseLine(input, lang); err != nil {
+					t.Errorf("%s: %v", lang, err)
+				}
+			}
+			parser.ParseLines(strings.Split(input, "\n"), lang)
+		}
+	})
+}
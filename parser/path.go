@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// --- Path Normalization ---
+
+// NormalizePath makes a reported path comparable across tools: absolute paths under
+// root become clean relative paths and a leading "./" is stripped, so "./main.go" and
+// "/src/app/main.go" (with root "/src/app") both become "main.go".
+// Paths outside root, and all paths when root is empty, are only stripped of "./".
+func NormalizePath(p, root string) string {
+	if root != "" && filepath.IsAbs(p) {
+		if rel, err := filepath.Rel(filepath.Clean(root), p); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return rel
+		}
+	}
+	for strings.HasPrefix(p, "./") {
+		p = p[2:]
+	}
+	return p
+}
+
+// modulePath matches a path in a dependency as printed by a -trimpath build, module@version/file:
+// example.com/foo@v1.2.3/bar/baz.go. Pseudo-versions and +incompatible are versions too.
+var modulePath = regexp.MustCompile(`^([^@\s/.][^@\s]*)@(v\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+incompatible)?)/(.+)$`)
+
+// ShortenModulePath removes the version from a path of a -trimpath build, so
+// "example.com/foo@v1.2.3/bar.go" becomes "example.com/foo/bar.go", or with keepModule
+// false the whole module prefix, leaving "bar.go". It also returns the module and version
+// it removed. Other paths, including those of the main module and the standard library,
+// which -trimpath prints without a version, are returned unchanged with an empty module.
+func ShortenModulePath(p string, keepModule bool) (short, module, version string) {
+	m := modulePath.FindStringSubmatch(p)
+	if m == nil {
+		return p, "", ""
+	}
+	if keepModule {
+		return m[1] + "/" + m[3], m[1], m[2]
+	}
+	return m[3], m[1], m[2]
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Perl Grammar ---
+// Example 1: Can't locate Foo/Bar.pm in @INC (you may need to install the Foo::Bar module) (@INC contains: /etc/perl ...) at script.pl line 3.
+// Example 2: syntax error at script.pl line 12, near "my ("
+// Example 3: Died at lib/App/Worker.pm line 42, <STDIN> line 1.
+// Perl puts the location at the end of the message, so the whole line is captured and
+// split at the last " at <file> line <N>" after parsing. Perl prints no columns.
+type PerlError struct {
+	Text Rest `parser:"@@"`
+
+	Filename string
+	Line     int
+	Message  string
+
+	Pos lexer.Position
+}
+
+// perlLocation matches the message, the " at <file> line <N>" location and what follows it.
+// The greedy message makes the last " at " the location, as messages may contain "at" themselves.
+var perlLocation = regexp.MustCompile(`^(.*\S) at (\S+) line (\d+)(.*)$`)
+
+// errNotPerl rejects lines without a Perl " at <file> line <N>" location.
+var errNotPerl = errors.New("no Perl location")
+
+// normalize splits the location off the message. A following `, near "..."` stays part
+// of the message; input positions like ", <STDIN> line 1." and the final period are dropped.
+func (e *PerlError) normalize() error {
+	m := perlLocation.FindStringSubmatch(strings.TrimSpace(string(e.Text)))
+	if m == nil {
+		return errNotPerl
+	}
+	e.Message, e.Filename = m[1], m[2]
+	e.Line, _ = strconv.Atoi(m[3])
+	if rest := strings.TrimSuffix(m[4], "."); strings.HasPrefix(rest, ", near ") {
+		e.Message += rest
+	}
+	return nil
+}
+
+// ToErrorInfo converts a parsed PerlError into the common ErrorInfo format.
+func (e *PerlError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Type:     "Error", // die and compile errors; Perl doesn't label them
+		Message:  e.Message,
+	}
+}
+
+// Perl parser instance
+var perlParser = participle.MustBuild[PerlError](commonParserOptions...)
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- PHP Grammar ---
+// Example 1: PHP Fatal error:  Uncaught Error: Call to undefined function foo() in /var/www/app.php:12
+// Example 2: PHP Warning:  Undefined variable $x in /var/www/app.php on line 5
+// Example 3: Parse error: syntax error, unexpected end of file in /var/www/app.php on line 20
+// Unlike the other languages the location comes last, as " in <path>:<line>" or
+// " in <path> on line <line>", so it is split off the message after parsing.
+type PHPError struct {
+	Level string `parser:"'PHP'? @('Fatal' | 'Parse' | 'Warning' | 'Notice' | 'Deprecated')"`
+	Text  Rest   `parser:"'error'? ':' @@"`
+
+	Filename string
+	Line     int
+	Message  string
+
+	Pos lexer.Position
+}
+
+// normalize splits the trailing " in <path>:<line>" location off the message.
+func (e *PHPError) normalize() error {
+	e.Message = strings.TrimSpace(string(e.Text))
+	in := strings.LastIndex(e.Message, " in ")
+	if in < 0 {
+		return nil
+	}
+	loc := e.Message[in+len(" in "):]
+	var file, line string
+	if before, after, ok := strings.Cut(loc, " on line "); ok {
+		file, line = before, after
+	} else if colon := strings.LastIndexByte(loc, ':'); colon > 0 {
+		file, line = loc[:colon], loc[colon+1:]
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(line, "."))
+	if file == "" || err != nil {
+		return nil // " in " was part of the message itself
+	}
+	e.Filename, e.Line, e.Message = file, n, e.Message[:in]
+	return nil
+}
+
+// ToErrorInfo converts a parsed PHPError into the common ErrorInfo format,
+// mapping PHP error levels onto the shared Error/Warning/Note types.
+func (e *PHPError) ToErrorInfo() ErrorInfo {
+	typ := "Error" // Fatal error, Parse error
+	switch e.Level {
+	case "Warning", "Deprecated":
+		typ = "Warning"
+	case "Notice":
+		typ = "Note"
+	}
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Type:     typ,
+		Message:  e.Message,
+	}
+}
+
+// PHP parser instance
+var phpParser = participle.MustBuild[PHPError](commonParserOptions...)
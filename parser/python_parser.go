@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Python Grammar ---
+// Python errors often span multiple lines. We'll parse key lines individually.
+// Example 1: File "/home/dima/projects/errorparser/gcd.py", line 1
+// Code run with -c or from stdin is reported as File "<string>" or File "<stdin>".
+type PythonFileRef struct {
+	Filename string `parser:"FileStart @(Path | '<' Word '>') \"\\\"\""` // Use Path inside quotes
+	Line     int    `parser:"',' 'line' @Number"`
+	Function Rest   `parser:"(',' 'in' @@)?"` // Optional enclosing scope, e.g. <module>
+
+	Pos lexer.Position
+}
+
+// Example 2: ModuleNotFoundError: No module named 'foowe'
+// Example 3: SyntaxError: '(' was never closed
+type PythonErrorLine struct {
+	ErrType string `parser:"@Word"` // e.g., ModuleNotFoundError, SyntaxError
+	Message Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// pythonOffset matches the "(offset N)" some tools append to syntax error messages.
+var pythonOffset = regexp.MustCompile(`\s*\(offset (\d+)\)$`)
+
+// ToErrorInfo converts a parsed PythonErrorLine into the common ErrorInfo format.
+// The location comes from a preceding PythonFileRef, which the Assembler attaches.
+// A trailing "(offset N)" becomes the column.
+func (e *PythonErrorLine) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     e.ErrType,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if m := pythonOffset.FindStringSubmatchIndex(info.Message); m != nil {
+		col, _ := strconv.Atoi(info.Message[m[2]:m[3]])
+		info.Column = &col
+		info.Message = info.Message[:m[0]]
+	}
+	return info
+}
+
+// PythonTraceback collects the `File "..."` frames of a traceback until its error line.
+// It is assembled by the Assembler rather than parsed from a single line.
+type PythonTraceback struct {
+	Frames []PythonFileRef  // Outermost call first, as Python prints them
+	Error  *PythonErrorLine // The final "ErrorType: message" line
+
+	snippet   string // Code line printed under the deepest frame
+	column    *int   // Column the caret line under snippet points at
+	endColumn *int   // Last column of a multi-character caret span
+}
+
+// addFrame appends the next `File "..."` frame; any snippet seen so far belonged to the previous one.
+func (t *PythonTraceback) addFrame(ref PythonFileRef) {
+	t.Frames = append(t.Frames, ref)
+	t.snippet, t.column, t.endColumn = "", nil, nil
+}
+
+// addExcerpt records a code snippet or caret line printed under the deepest frame.
+// The caret position is relative to the snippet as printed, which is dedented, so the
+// column counts from the first non-blank character of the source line.
+func (t *PythonTraceback) addExcerpt(line string) {
+	marks := strings.TrimSpace(line)
+	if t.snippet == "" || marks == "" || strings.Trim(marks, "^~") != "" {
+		t.snippet, t.column, t.endColumn = line, nil, nil
+		return
+	}
+	indent := len(t.snippet) - len(strings.TrimLeft(t.snippet, " "))
+	start := strings.IndexAny(line, "^~") - indent + 1
+	end := strings.LastIndexAny(line, "^~") - indent + 1
+	if start < 1 {
+		return
+	}
+	t.column = &start
+	if end > start {
+		t.endColumn = &end
+	}
+}
+
+// ToErrorInfo converts a traceback into the common ErrorInfo format.
+// The deepest frame, where the error was raised, provides the location.
+// A traceback cut off before its error line is reported with type "Error" and no message.
+func (t *PythonTraceback) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{Type: "Error"}
+	if t.Error != nil {
+		info = t.Error.ToErrorInfo()
+	}
+	if len(t.Frames) > 0 {
+		info.ParsePos = parsePos(t.Frames[0].Pos) // The traceback starts at its first frame
+	}
+	for _, ref := range t.Frames {
+		info.Frames = append(info.Frames, Frame{
+			Filename: ref.Filename,
+			Line:     ref.Line,
+			Function: strings.TrimSpace(string(ref.Function)),
+		})
+	}
+	if n := len(t.Frames); n > 0 {
+		info.Filename = t.Frames[n-1].Filename
+		info.Line = t.Frames[n-1].Line
+		if t.column != nil {
+			info.Column = t.column
+		}
+		if t.endColumn != nil {
+			line := info.Line
+			info.EndLine, info.EndColumn = &line, t.endColumn
+		}
+	}
+	return info
+}
+
+// Example 4: FAILED tests/test_cart.py::TestCart::test_total[empty] - AssertionError: assert 0 == 1
+// Example 5: ERROR tests/test_api.py - ModuleNotFoundError: No module named 'requests'
+// pytest's short test summary: the outcome, the test node id (file, then "::"-separated
+// class and test names) and, after " - ", the exception. Node ids may contain brackets
+// and spaces, so the part after the file is split after parsing.
+type PytestResult struct {
+	Outcome  string `parser:"@('FAILED' | 'ERROR')"`
+	Filename string `parser:"@Path"`
+	Rest     Rest   `parser:"@@"` // e.g. ::test_total[empty] - AssertionError: assert 0 == 1
+
+	Test      string // Node id after the file, e.g. TestCart::test_total[empty]
+	Exception string // e.g. AssertionError, empty for bare assert messages
+	Message   string
+
+	Pos lexer.Position
+}
+
+// pytestException matches a leading exception type such as AssertionError or requests.HTTPError.
+var pytestException = regexp.MustCompile(`^([A-Za-z_][\w.]*(?:Error|Exception|Exit|Interrupt|Warning|Failed)): ?(.*)$`)
+
+// errNotPytest rejects lines that merely start with FAILED or ERROR.
+var errNotPytest = errors.New("not a pytest summary line")
+
+// normalize splits the node id from the exception and message.
+func (r *PytestResult) normalize() error {
+	node, detail, _ := strings.Cut(strings.TrimRight(string(r.Rest), " "), " - ")
+	if node = strings.TrimSpace(node); node != "" && !strings.HasPrefix(node, "::") {
+		return errNotPytest
+	}
+	r.Test = strings.TrimPrefix(node, "::")
+	r.Message = strings.TrimSpace(detail)
+	if m := pytestException.FindStringSubmatch(r.Message); m != nil {
+		r.Exception, r.Message = m[1], m[2]
+	}
+	return nil
+}
+
+// ToErrorInfo converts a pytest summary line into the common ErrorInfo format.
+// The exception type becomes the type; failures without one are reported as TestFailure.
+// The summary carries no line number.
+func (r *PytestResult) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(r.Pos),
+		Filename: r.Filename,
+		Type:     r.Exception,
+		Message:  r.Message,
+	}
+	if info.Type == "" {
+		info.Type = "TestFailure"
+	}
+	switch {
+	case r.Test != "" && info.Message != "":
+		info.Message = r.Test + ": " + info.Message
+	case r.Test != "":
+		info.Message = r.Test + " failed"
+	}
+	return info
+}
+
+// errLogLevel rejects "LEVEL: message" lines that look like exception lines.
+var errLogLevel = errors.New("log level, not an exception")
+
+// errNotException rejects lowercase "word: message" lines, such as the "warning:" and
+// "error:" lines of compilers, as exception classes are capitalized.
+var errNotException = errors.New("lowercase word, not an exception")
+
+// --- Python Specific Grammar ---
+// PythonParseResult holds the result of parsing a single line of Python output.
+type PythonParseResult struct {
+	FileRef *PythonFileRef   `parser:"( @@ EOL?"`
+	Error   *PythonErrorLine `parser:"| @@ EOL?"`
+	Pytest  *PytestResult    `parser:"| @@ EOL? )"`
+}
+
+// normalize post-processes pytest summary lines, see PytestResult.normalize.
+// It also rejects all-caps log level prefixes such as "ERROR:" or "INFO:" (from Bazel,
+// loggers and build tools), and lowercase prefixes such as rustc's "warning:", which
+// Python exception names never are.
+func (r *PythonParseResult) normalize() error {
+	if r.Error != nil && r.Error.ErrType == strings.ToUpper(r.Error.ErrType) {
+		return errLogLevel
+	}
+	if r.Error != nil && r.Error.ErrType[0] >= 'a' && r.Error.ErrType[0] <= 'z' {
+		return errNotException
+	}
+	if r.Pytest != nil {
+		return r.Pytest.normalize()
+	}
+	return nil
+}
+
+// Python parser instance
+var pythonParser = participle.MustBuild[PythonParseResult](
+	append(commonParserOptions, participle.UseLookahead(1))..., // Python grammar might be simpler
+)
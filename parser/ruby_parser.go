@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Ruby Grammar ---
+// Example 1: foo.rb:5:in '<main>': undefined method 'bar' for nil (NoMethodError)
+// Example 2: foo.rb:5:in `<main>': undefined method `bar' for nil:NilClass (NoMethodError)
+// Example 3: 	from foo.rb:3:in 'Object#baz'
+// Example 4: foo.rb:7: warning: assigned but unused variable - x
+// Ruby 3.4 quotes method names as 'name', older versions as `name'. The method
+// context, message and exception class are split out of the free text after parsing.
+
+// RubyError captures the first line of an uncaught Ruby exception or a Ruby warning.
+type RubyError struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Text     Rest   `parser:"':' @@"`
+
+	Method  string // Method context, e.g. <main> or Integer#+
+	Message string
+	Class   string // Exception class from the trailing parentheses, e.g. NoMethodError
+
+	Frames []RubyFrame // Filled in by the Assembler from the following `from` lines
+
+	Pos lexer.Position
+}
+
+// RubyFrame is a `from file:line:in 'method'` backtrace line following a RubyError.
+type RubyFrame struct {
+	Filename string `parser:"'from' @Path"`
+	Line     int    `parser:"':' @Number"`
+	Text     Rest   `parser:"( ':' @@ )?"`
+
+	Method string
+
+	Pos lexer.Position
+}
+
+// splitRubyMethod splits an "in 'method': rest" prefix off text, accepting both quoting styles.
+func splitRubyMethod(text string) (method, rest string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "in '") && !strings.HasPrefix(text, "in `") {
+		return "", text
+	}
+	end := strings.Index(text[4:], "'")
+	if end < 0 {
+		return "", text
+	}
+	method = text[4 : 4+end]
+	rest = strings.TrimPrefix(text[4+end+1:], ":")
+	return method, strings.TrimSpace(rest)
+}
+
+// ToErrorInfo converts a parsed RubyError and its backtrace into the common ErrorInfo format.
+// The exception class becomes the Type; warnings map to "Warning". An error naming its
+// method is the innermost frame of the backtrace; warnings have no frames.
+func (e *RubyError) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Type:     e.Class,
+		Message:  e.Message,
+	}
+	if e.Method != "" {
+		frames := append([]RubyFrame{{Filename: e.Filename, Line: e.Line, Method: e.Method}}, e.Frames...)
+		// Ruby prints the innermost call first; Frames lists the outermost call first
+		for i := len(frames) - 1; i >= 0; i-- {
+			info.Frames = append(info.Frames, Frame{Filename: frames[i].Filename, Line: frames[i].Line, Function: frames[i].Method})
+		}
+	}
+	if msg, ok := strings.CutPrefix(e.Message, "warning: "); ok && e.Class == "" {
+		info.Type, info.Message = "Warning", msg
+	}
+	if info.Type == "" {
+		info.Type = "Error"
+	}
+	return info
+}
+
+// --- Ruby Specific Grammar ---
+// RubyParseResult holds the result of parsing a single line of Ruby output.
+type RubyParseResult struct {
+	Frame *RubyFrame `parser:"( @@ EOL?"`
+	Error *RubyError `parser:"| @@ EOL? )"`
+}
+
+// normalize splits the method context, message and exception class out of the captured text.
+func (r *RubyParseResult) normalize() error {
+	if r.Frame != nil {
+		r.Frame.Method, _ = splitRubyMethod(string(r.Frame.Text))
+	}
+	if r.Error != nil {
+		e := r.Error
+		e.Method, e.Message = splitRubyMethod(string(e.Text))
+		if open := strings.LastIndex(e.Message, " ("); open >= 0 && strings.HasSuffix(e.Message, ")") {
+			if class := e.Message[open+2 : len(e.Message)-1]; !strings.ContainsAny(class, " ()") {
+				e.Class, e.Message = class, e.Message[:open]
+			}
+		}
+	}
+	return nil
+}
+
+// Ruby parser instance
+var rubyParser = participle.MustBuild[RubyParseResult](
+	append(commonParserOptions, participle.UseLookahead(1))...,
+)
@@ -0,0 +1,305 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Rust Grammar ---
+// Example 1: error[E0308]: mismatched types
+// Example 2:  --> src/main.rs:5:5
+// Example 3: warning: unused variable: `x`
+// Example 4:   = note: expected type `i32`
+// Example 5:   |     ^^^^^^^^^^^ expected `i32`, found `&str`
+// The location usually arrives on the line after the message, followed by the source
+// excerpt and note/help lines; the Assembler joins them until the diagnostic ends.
+
+// RustError captures the primary information from a Rust compiler error or warning line.
+type RustMsgLine struct {
+	Level    string        `parser:"@('error' | 'warning')"`            // "error" or "warning"
+	Code     *string       `parser:"( LBracket @ErrorCode RBracket )?"` // Optional error code like [E0308]
+	Message  Rest          `parser:"':' @@ EOL?"`
+	Location *RustLocation `parser:"( @@ )?"` // Optional location line immediately following
+
+	Pos lexer.Position
+}
+
+// RustLocation captures the file path, line, and column.
+// An optional trailing range gives the end of the span, either as an end column
+// on the same line (src/main.rs:5:5-16) or as line:column (src/main.rs:5:5-7:2).
+type RustLocation struct {
+	Filename string `parser:"Arrow @Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   int    `parser:"':' @Number"`
+	RangeEnd *int   `parser:"( '-' @Number"`
+	RangeCol *int   `parser:"  ( ':' @Number )? )?"`
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a parsed RustMsgLine into the common ErrorInfo format.
+func (e *RustMsgLine) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     capitalize(e.Level), // Capitalize "error" -> "Error", "warning" -> "Warning"
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	if e.Code != nil {
+		info.Code = *e.Code
+	}
+	if e.Location != nil {
+		e.Location.applyTo(&info)
+	}
+	return info
+}
+
+// rustSummary matches the counts rustc and cargo print once compilation ends, e.g.
+// "aborting due to 2 previous errors", "3 warnings emitted",
+// "`app` (lib) generated 1 warning" and "could not compile `app` ...".
+var rustSummary = regexp.MustCompile(`^(aborting due to (\d+ )?previous errors?\b|\d+ warnings? emitted$|\S+( \([^)]*\))? generated \d+ warnings?\b|could not compile )`)
+
+// errNotRustDiagnostic rejects summary lines, which repeat the count of the diagnostics.
+var errNotRustDiagnostic = errors.New("not a rust diagnostic")
+
+// normalize rejects the summary lines that follow the diagnostics, see rustSummary.
+func (e *RustMsgLine) normalize() error {
+	if e.Code == nil && e.Location == nil && rustSummary.MatchString(strings.TrimSpace(string(e.Message))) {
+		return errNotRustDiagnostic
+	}
+	return nil
+}
+
+// applyTo fills the location fields of info from the parsed RustLocation.
+func (l *RustLocation) applyTo(info *ErrorInfo) {
+	info.Filename = l.Filename
+	info.Line = l.Line
+	col := l.Column // Assign to temp var to take address
+	info.Column = &col
+	switch {
+	case l.RangeEnd != nil && l.RangeCol != nil:
+		info.EndLine, info.EndColumn = l.RangeEnd, l.RangeCol
+	case l.RangeEnd != nil:
+		line := l.Line // Range ends on the same line
+		info.EndLine, info.EndColumn = &line, l.RangeEnd
+	}
+}
+
+// RustNote is a note or help line attached to the diagnostic above it, either
+// top-level ("help: ...") or in the excerpt ("  = note: ...").
+type RustNote struct {
+	Kind string `parser:"'='? @('note' | 'help')"`
+	Text Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// String returns the note as printed, e.g. "note: expected type `i32`".
+func (n *RustNote) String() string {
+	return n.Kind + ": " + strings.TrimSpace(string(n.Text))
+}
+
+// RustGutter is a line of the source excerpt, e.g. "5 |     let y: i32 = x;" or
+// the underline "  |     ^^^^^^^^^^^ expected `i32`, found `&str`".
+type RustGutter struct {
+	Text Rest `parser:"Number? '|' @@"`
+}
+
+// underline returns the columns marked by a primary "^^^" underline and its label.
+func (g *RustGutter) underline() (start, end int, label string, ok bool) {
+	text := strings.TrimPrefix(string(g.Text), " ") // rustc separates the gutter with one space
+	i := strings.IndexByte(text, '^')
+	if i < 0 || strings.TrimSpace(text[:i]) != "" {
+		return 0, 0, "", false
+	}
+	n := len(text[i:]) - len(strings.TrimLeft(text[i:], "^"))
+	return i + 1, i + n, strings.TrimSpace(text[i+n:]), true
+}
+
+// --- Rust Panics ---
+// Example 1 (Rust 1.73 and later, the message follows on its own lines):
+//
+//	thread 'main' panicked at src/main.rs:10:5:
+//	index out of bounds: the len is 3 but the index is 10
+//	note: run with `RUST_BACKTRACE=1` environment variable to display a backtrace
+//
+// Example 2 (older releases, a multi-line message continues until the location):
+//
+//	thread 'main' panicked at 'index out of bounds: the len is 3 but the index is 10', src/main.rs:10:5
+//
+// With RUST_BACKTRACE=1 a "stack backtrace:" follows, whose numbered function lines
+// may each be followed by an "at file:line:col" line. The Assembler collects both.
+
+// RustPanic is the "thread '...' panicked at" header of a Rust panic.
+type RustPanic struct {
+	Text Rest `parser:"'thread' @@"`
+
+	Thread   string
+	Message  string
+	Filename string
+	Line     int
+	Column   *int
+	Notes    []string    // Filled in by the Assembler from the following "note:" lines
+	Frames   []RustFrame // Filled in by the Assembler from the backtrace, innermost call first
+
+	open     bool // Old layout whose quoted message continues on the next line
+	awaiting bool // New layout whose message lines follow
+	inTrace  bool // Whether the "stack backtrace:" line was seen
+
+	Pos lexer.Position
+}
+
+// RustFrame is one function of a Rust backtrace and, if printed, its location.
+type RustFrame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+var (
+	// rustPanicHeader splits "'thread' panicked at rest".
+	rustPanicHeader = regexp.MustCompile(`^\s*'([^']*)' panicked at (.*)$`)
+	// rustPanicLocation matches the location of the new layout, "src/main.rs:10:5:".
+	rustPanicLocation = regexp.MustCompile(`^(\S+?):(\d+)(?::(\d+))?:$`)
+	// rustPanicEnd matches the end of an old layout message, "...', src/main.rs:10:5".
+	rustPanicEnd = regexp.MustCompile(`^(.*)', (\S+?):(\d+)(?::(\d+))?$`)
+	// rustTraceFunction and rustTraceAt match the lines of a backtrace.
+	rustTraceFunction = regexp.MustCompile(`^\s*\d+: (.+)$`)
+	rustTraceAt       = regexp.MustCompile(`^\s+at (\S+?):(\d+)(?::\d+)?$`)
+)
+
+// errNotRustPanic rejects other "thread ..." lines, e.g. "thread 'main' has overflowed its stack".
+var errNotRustPanic = errors.New("not a rust panic header")
+
+// normalize splits the header into the thread name and, depending on the layout,
+// the location or the start of the message.
+func (p *RustPanic) normalize() error {
+	m := rustPanicHeader.FindStringSubmatch(strings.TrimRight(string(p.Text), " \t\r"))
+	if m == nil {
+		return errNotRustPanic
+	}
+	p.Thread = m[1]
+	rest := m[2]
+	if loc := rustPanicLocation.FindStringSubmatch(rest); loc != nil {
+		p.setLocation(loc[1:])
+		p.awaiting = true
+		return nil
+	}
+	quoted, ok := strings.CutPrefix(rest, "'")
+	if !ok {
+		return errNotRustPanic
+	}
+	p.open = true
+	p.addLine(quoted)
+	return nil
+}
+
+// setLocation sets the location from the file, line and optional column submatches.
+func (p *RustPanic) setLocation(loc []string) {
+	p.Filename = loc[0]
+	p.Line, _ = strconv.Atoi(loc[1])
+	if loc[2] != "" {
+		col, _ := strconv.Atoi(loc[2])
+		p.Column = &col
+	}
+}
+
+// addLine takes the next line after the header and reports whether it belongs to
+// the panic: a message line, the "stack backtrace:" line or a backtrace line.
+func (p *RustPanic) addLine(line string) bool {
+	switch {
+	case p.open:
+		if m := rustPanicEnd.FindStringSubmatch(strings.TrimRight(line, " \t\r")); m != nil {
+			p.appendMessage(m[1])
+			p.setLocation(m[2:])
+			p.open = false
+		} else {
+			p.appendMessage(line)
+		}
+		return true
+	case strings.TrimSpace(line) == "stack backtrace:":
+		p.awaiting, p.inTrace = false, true
+		return true
+	case p.inTrace:
+		if m := rustTraceFunction.FindStringSubmatch(line); m != nil {
+			p.Frames = append(p.Frames, RustFrame{Function: strings.TrimSpace(m[1])})
+			return true
+		}
+		if m := rustTraceAt.FindStringSubmatch(strings.TrimRight(line, " \t\r")); m != nil && len(p.Frames) > 0 {
+			frame := &p.Frames[len(p.Frames)-1]
+			frame.File = m[1]
+			frame.Line, _ = strconv.Atoi(m[2])
+			return true
+		}
+		return false
+	case p.awaiting:
+		p.appendMessage(line)
+		return true
+	}
+	return false
+}
+
+// appendMessage adds a message line, joining wrapped lines with a space.
+func (p *RustPanic) appendMessage(line string) {
+	if line = strings.TrimSpace(line); line == "" {
+		return
+	}
+	if p.Message != "" {
+		p.Message += " "
+	}
+	p.Message += line
+}
+
+// ToErrorInfo converts a panic into the common ErrorInfo format. The header gives the
+// location; backtrace functions without a location are left out of the frames.
+func (p *RustPanic) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(p.Pos),
+		Filename: p.Filename,
+		Line:     p.Line,
+		Column:   p.Column,
+		Type:     "Panic",
+		Message:  p.Message,
+		Notes:    p.Notes,
+		Thread:   p.Thread,
+	}
+	// Rust prints the innermost call first; Frames lists the outermost call first
+	for i := len(p.Frames) - 1; i >= 0; i-- {
+		if f := p.Frames[i]; f.File != "" {
+			info.Frames = append(info.Frames, Frame{Filename: f.File, Line: f.Line, Function: f.Function})
+		}
+	}
+	return info
+}
+
+// --- Rust Specific Grammar ---
+// RustParseResult holds the result of parsing a single line of Rust output:
+// a message line, a standalone location line, a note, a source excerpt line or a panic.
+type RustParseResult struct {
+	Message  *RustMsgLine  `parser:"( @@ EOL?"`
+	Location *RustLocation `parser:"| @@ EOL?"`
+	Note     *RustNote     `parser:"| @@ EOL?"`
+	Gutter   *RustGutter   `parser:"| @@ EOL?"`
+	Panic    *RustPanic    `parser:"| @@ EOL? )"`
+}
+
+// normalize rejects summary lines and splits panic headers, see RustMsgLine.normalize
+// and RustPanic.normalize.
+func (r *RustParseResult) normalize() error {
+	if r.Message != nil {
+		return r.Message.normalize()
+	}
+	if r.Panic != nil {
+		return r.Panic.normalize()
+	}
+	return nil
+}
+
+// Rust parser instance
+var rustParser = participle.MustBuild[RustParseResult](
+	append(commonParserOptions, participle.UseLookahead(2))..., // Lookahead might be needed
+)
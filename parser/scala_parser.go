@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Scala Grammar (sbt) ---
+// Example 1: [error] /src/main/scala/Main.scala:10:5: not found: value foo
+// Example 2: [warn] /src/main/scala/Util.scala:3: match may not be exhaustive.
+// Example 3: [error]   foo(1)
+// Every sbt line carries a bracketed level tag. Only lines with a location start a
+// diagnostic; the other tagged lines (code snippets, carets, totals) are matched as context.
+type ScalaLine struct {
+	Level    string         `parser:"LBracket @('error' | 'warn' | 'info') RBracket"`
+	Location *ScalaLocation `parser:"@@?"`
+	Message  Rest           `parser:"@@"`
+
+	Pos lexer.Position
+}
+
+// ScalaLocation is the file:line[:col]: prefix of an sbt diagnostic; the column is
+// omitted by older compiler versions.
+type ScalaLocation struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   *int   `parser:"(':' @Number)? ':'"`
+}
+
+// ToErrorInfo converts a parsed ScalaLine into the common ErrorInfo format.
+func (e *ScalaLine) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Type:     "Error",
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+	switch e.Level {
+	case "warn":
+		info.Type = "Warning"
+	case "info":
+		info.Type = "Note"
+	}
+	if e.Location != nil {
+		info.Filename = e.Location.Filename
+		info.Line = e.Location.Line
+		info.Column = e.Location.Column
+	}
+	return info
+}
+
+// Scala parser instance
+// The location is optional, so the parser needs lookahead to back out of a
+// tagged line that merely starts with a path-like word.
+var scalaParser = participle.MustBuild[ScalaLine](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,75 @@
+package parser
+
+import "strings"
+
+// --- Severity ---
+
+// Severity orders ErrorInfo types from least to most severe, so output can be
+// filtered with a simple comparison.
+type Severity int
+
+const (
+	SevNote Severity = iota
+	SevWarning
+	SevError
+)
+
+// String returns the lowercase name of the severity as accepted by ParseSeverity.
+func (s Severity) String() string {
+	switch s {
+	case SevNote:
+		return "note"
+	case SevWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// ParseSeverity parses a severity name (note, warning or error), ignoring case.
+func ParseSeverity(name string) (Severity, bool) {
+	switch strings.ToLower(name) {
+	case "note":
+		return SevNote, true
+	case "warning":
+		return SevWarning, true
+	case "error":
+		return SevError, true
+	}
+	return SevError, false
+}
+
+// severityTypes normalizes the lowercased Type strings produced by the built-in
+// parsers, and the level names common in custom patterns, onto a Severity. "Unknown" is
+// what LooseMatch reports for lines that merely contain a location, often informational
+// ones like "INFO loading config from app.yaml:12", so it must not fail a run by itself.
+var severityTypes = map[string]Severity{
+	"note":        SevNote,
+	"notice":      SevNote,
+	"help":        SevNote,
+	"hint":        SevNote,
+	"info":        SevNote,
+	"information": SevNote,
+	"warning":     SevWarning,
+	"warn":        SevWarning,
+	"deprecated":  SevWarning,
+	"unknown":     SevWarning,
+	"error":       SevError,
+	"fatal":       SevError,
+	"panic":       SevError,
+}
+
+// SeverityOf maps an ErrorInfo Type onto a Severity using severityTypes, ignoring case.
+// Types differ per language ("Warning", "note", "Panic", "java.lang.NullPointerException"),
+// so anything not in the table (exceptions, test failures, data races) counts as an error.
+func SeverityOf(typ string) Severity {
+	if sev, ok := severityTypes[strings.ToLower(typ)]; ok {
+		return sev
+	}
+	return SevError
+}
+
+// Severity returns the severity of the error, see SeverityOf.
+func (e ErrorInfo) Severity() Severity {
+	return SeverityOf(e.Type)
+}
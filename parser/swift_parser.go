@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Swift Grammar (swiftc) ---
+// Example 1: main.swift:10:5: error: cannot find 'foo' in scope
+// Example 2: /Users/me/My Project/Sources/App/Model.swift:3:9: warning: initialization of immutable value 'x' was never used
+// Example 3: /Users/me/My Project/Sources/App/Model.swift:1:6: note: 'Model' declared here
+// Same shape as Kotlin, but Xcode projects commonly live in directories with spaces.
+type SwiftError struct {
+	Filename SpacedPath `parser:"@@"`
+	Line     int        `parser:"':' @Number"`
+	Column   int        `parser:"':' @Number"`
+	Severity string     `parser:"':' @('error' | 'warning' | 'note')"`
+	Message  Rest       `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// errNotSwiftFile rejects diagnostics for non-Swift sources (e.g. "main.cpp:1:2: error: ...").
+var errNotSwiftFile = errors.New("not a Swift source file")
+
+func (e *SwiftError) normalize() error {
+	if !strings.HasSuffix(string(e.Filename), ".swift") {
+		return errNotSwiftFile
+	}
+	return nil
+}
+
+// ToErrorInfo converts a parsed SwiftError into the common ErrorInfo format.
+func (e *SwiftError) ToErrorInfo() ErrorInfo {
+	col := e.Column
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: string(e.Filename),
+		Line:     e.Line,
+		Column:   &col,
+		Type:     capitalize(e.Severity), // "error" -> "Error"
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// Swift parser instance
+var swiftParser = participle.MustBuild[SwiftError](commonParserOptions...)
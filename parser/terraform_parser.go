@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Terraform Grammar ---
+// Example:
+//
+//	╷
+//	│ Error: Invalid resource type
+//	│
+//	│   on main.tf line 10, in resource "aws_s3_bukcet" "logs":
+//	│   10: resource "aws_s3_bukcet" "logs" {
+//	│
+//	│ The provider hashicorp/aws does not support resource type "aws_s3_bukcet".
+//	╵
+//
+// Older versions and -no-color output drop the box drawing characters. The summary line
+// starts a diagnostic, the "on <file> line <N>" line locates it and the detail paragraphs
+// become notes; the Assembler puts them together.
+
+// TerraformDiagnostic is the "Error: summary" line starting a Terraform diagnostic.
+type TerraformDiagnostic struct {
+	Severity string `parser:"'│'? @('Error' | 'Warning')"`
+	Summary  Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// TerraformLocation is the "on <file> line <N>" line of a Terraform diagnostic.
+type TerraformLocation struct {
+	Filename string `parser:"'│'? 'on' @Path"`
+	Line     int    `parser:"'line' @Number"`
+	Context  Rest   `parser:"( ',' @@ )?"` // e.g. in resource "aws_s3_bucket" "logs":
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts the summary line into the common ErrorInfo format.
+// The location and details are added by the Assembler.
+func (d *TerraformDiagnostic) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(d.Pos),
+		Type:     d.Severity,
+		Message:  strings.TrimSpace(string(d.Summary)),
+	}
+}
+
+// terraformExcerpt matches a numbered source line quoted under the location, e.g. "10: resource ...".
+var terraformExcerpt = regexp.MustCompile(`^\d+:`)
+
+// terraformDetail returns the text of a detail line inside a diagnostic box, without the
+// box border. ok is false for source excerpts and expression value markers, which aren't details.
+func terraformDetail(line string) (text string, ok bool) {
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "│"))
+	if terraformExcerpt.MatchString(text) || strings.HasPrefix(text, "├") || strings.HasPrefix(text, "│") {
+		return "", false
+	}
+	return text, true
+}
+
+// --- Terraform Specific Grammar ---
+// TerraformParseResult holds the result of parsing a single line of Terraform output.
+type TerraformParseResult struct {
+	Diagnostic *TerraformDiagnostic `parser:"( @@ EOL?"`
+	Location   *TerraformLocation   `parser:"| @@ EOL?"`
+	BoxEdge    string               `parser:"| @('╷' | '╵') EOL? )"` // Top and bottom of a diagnostic box
+}
+
+// Terraform parser instance
+var terraformParser = participle.MustBuild[TerraformParseResult](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
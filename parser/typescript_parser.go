@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- TypeScript Grammar ---
+// Example 1: src/app.ts:12:5 - error TS2322: Type 'string' is not assignable to type 'number'.
+// Example 2: src/app.ts:12 - warning TS6133: 'x' is declared but its value is never read.
+// tsc separates the location from the severity with " - " and may omit the column.
+type TypeScriptError struct {
+	Filename string `parser:"@Path"`
+	Line     int    `parser:"':' @Number"`
+	Column   *int   `parser:"(':' @Number)?"` // Optional column
+	Severity string `parser:"'-' @('error' | 'warning')"`
+	Code     string `parser:"@TSCode"` // e.g. TS2322
+	Message  Rest   `parser:"':' @@"`
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts a parsed TypeScriptError into the common ErrorInfo format.
+func (e *TypeScriptError) ToErrorInfo() ErrorInfo {
+	return ErrorInfo{
+		ParsePos: parsePos(e.Pos),
+		Filename: e.Filename,
+		Line:     e.Line,
+		Column:   e.Column,
+		Type:     capitalize(e.Severity), // Capitalize "error" -> "Error", "warning" -> "Warning"
+		Code:     e.Code,
+		Message:  strings.TrimSpace(string(e.Message)),
+	}
+}
+
+// TypeScript parser instance
+var typeScriptParser = participle.MustBuild[TypeScriptError](commonParserOptions...)
@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// --- Webpack Grammar ---
+// Example:
+//
+//	ERROR in ./src/index.js 10:5-24
+//	Module not found: Error: Can't resolve './missing' in '/home/me/app/src'
+//
+// Other header shapes: "WARNING in ./src/a.js 2:12-3:4" (a multi-line span),
+// "ERROR in src/app.ts:10:5" (fork-ts-checker), "ERROR in /app/src/app.ts(10,5)"
+// (ts-loader) and "ERROR in ./src/styles.css" without a location.
+// The message follows on the next line; the Assembler folds it into the header, and the
+// detail lines after it up to a blank line into Raw.
+type WebpackHeader struct {
+	Severity string         `parser:"@('ERROR' | 'WARNING') 'in'"`
+	Filename string         `parser:"@Path"`
+	Paren    *ParenLocation `parser:"( @@"`
+	Colon    bool           `parser:"| @':'?"` // fork-ts-checker's file:line:col, which counts columns from 1
+	Line     int            `parser:"  @Number"`
+	Column   *int           `parser:"  ':' @Number"`
+	SpanA    *int           `parser:"  ( '-' @Number"`            // End column, or end line if SpanB follows
+	SpanB    *int           `parser:"    ( ':' @Number )? )? )?"` // End column of a multi-line span
+	Tail     Rest           `parser:"@@"`                         // e.g. " (from ts-loader)"
+
+	Pos lexer.Position
+}
+
+// ToErrorInfo converts the header into the common ErrorInfo format. The message is added by the Assembler.
+func (h *WebpackHeader) ToErrorInfo() ErrorInfo {
+	info := ErrorInfo{
+		ParsePos: parsePos(h.Pos),
+		Filename: h.Filename,
+		Line:     h.Line,
+		Column:   h.Column,
+		Type:     capitalize(h.Severity), // "ERROR" -> "Error"
+	}
+	switch {
+	case h.Paren != nil:
+		info.Line, info.Column = h.Paren.Line, h.Paren.Column
+		info.EndLine, info.EndColumn = h.Paren.EndLine, h.Paren.EndColumn
+	case h.SpanB != nil:
+		info.EndLine, info.EndColumn = h.SpanA, h.SpanB
+	case h.SpanA != nil:
+		line := h.Line
+		info.EndLine, info.EndColumn = &line, h.SpanA
+	}
+	if h.Column != nil && !h.Colon {
+		col := *h.Column + 1 // Webpack counts columns from 0; its exclusive end column needs no change
+		info.Column = &col
+	}
+	return info
+}
+
+// Webpack parser instance
+var webpackParser = participle.MustBuild[WebpackHeader](
+	append(commonParserOptions, participle.UseLookahead(participle.MaxLookahead))...,
+)
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// --- Custom Pattern Files ---
+// A pattern file for -lang custom is a flat YAML mapping:
+//
+//	name: mytool
+//	pattern: '^(?P<path>[^:]+):(?P<line>\d+): (?P<type>ERROR|WARN) (?P<message>.*)$'
+//	filename: path
+//
+// pattern is required. The optional keys filename, line, column, type, code and message
+// name the capture group holding that field when it differs from the field name.
+// Single-quoted values are taken literally, so regular expressions need no extra escaping.
+// Double-quoted values process escapes as in YAML (and Go): "\\d+" is the pattern \d+,
+// while "\d+" is an error, as \d is not an escape.
+
+// loadCustomLanguage reads a pattern file and registers the language it describes.
+func loadCustomLanguage(path string) (parser.Language, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return parser.LangUnknown, err
+	}
+	defer f.Close()
+
+	name, expr := "custom", ""
+	mapping := parser.FieldMap{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return parser.LangUnknown, fmt.Errorf("%s:%d: expected key: value", path, n)
+		}
+		value, err := unquoteYAML(strings.TrimSpace(value))
+		if err != nil {
+			return parser.LangUnknown, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		switch key = strings.TrimSpace(key); key {
+		case "name":
+			name = value
+		case "pattern":
+			expr = value
+		default:
+			mapping[key] = value // Validated by RegisterCustomLanguage
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return parser.LangUnknown, err
+	}
+	if expr == "" {
+		return parser.LangUnknown, fmt.Errorf("%s: missing pattern", path)
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return parser.LangUnknown, fmt.Errorf("%s: invalid pattern: %v", path, err)
+	}
+	lang, err := parser.RegisterCustomLanguage(name, pattern, mapping)
+	if err != nil {
+		return parser.LangUnknown, fmt.Errorf("%s: %v", path, err)
+	}
+	return lang, nil
+}
+
+// unquoteYAML strips YAML single or double quotes from a scalar value.
+func unquoteYAML(value string) (string, error) {
+	switch {
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid double-quoted value %s, single-quote it to take backslashes literally", value)
+		}
+		return s, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/festeh/errorparser/parser"
+)
+
+// writePatternFile writes a pattern file to a temporary directory and returns its path.
+// The name of the language is the test's, as every language registers once per process.
+func writePatternFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pattern.yml")
+	data = strings.ReplaceAll(data, "NAME", strings.ReplaceAll(t.Name(), "/", "-"))
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCustomLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		line string
+		want string // The error parsed from line, as file:line:column Type: message
+	}{
+		{
+			name: "single-quoted pattern taken literally",
+			data: "name: NAME\npattern: '^(?P<path>[^:]+):(?P<line>\\d+): (?P<type>ERROR|WARN) (?P<message>.*)$'\nfilename: path\n",
+			line: "src/app.c:12: WARN unused value",
+			want: "src/app.c:12:0 Warning: unused value",
+		},
+		{
+			name: "quotes doubled in single quotes",
+			data: "name: NAME\npattern: '^(?P<filename>\\S+) ''(?P<message>[^'']*)''$'\n",
+			line: "app.cfg 'bad key'",
+			want: "app.cfg:0:0 Error: bad key",
+		},
+		{
+			name: "escapes in double quotes",
+			data: "name: \"NAME\"\npattern: \"^(?P<filename>\\\\S+)\\t(?P<line>\\\\d+)\\t(?P<message>.*)$\"\n",
+			line: "main.tf\t3\tunknown \"x\"",
+			want: "main.tf:3:0 Error: unknown \"x\"",
+		},
+		{
+			name: "plain values, comments and mapped fields",
+			data: "# Lint output\n\nname: NAME\npattern: ^(?P<f>\\S+):(?P<l>\\d+):(?P<c>\\d+) (?P<id>\\w+) (?P<m>.*)$\nfilename: f\nline: l\ncolumn: c\ncode: id\nmessage: m\n",
+			line: "lib/a.py:4:2 W123 line too long",
+			want: "lib/a.py:4:2 Error: line too long",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, err := loadCustomLanguage(writePatternFile(t, tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := parser.ParseLine(tt.line, lang)
+			if err != nil {
+				t.Fatal(err)
+			}
+			e, ok := result.(*parser.CustomError)
+			if !ok {
+				t.Fatalf("%q not matched, got %T", tt.line, result)
+			}
+			info := e.ToErrorInfo()
+			col := 0
+			if info.Column != nil {
+				col = *info.Column
+			}
+			if got := info.Filename + ":" + strconv.Itoa(info.Line) + ":" + strconv.Itoa(col) + " " + info.Type + ": " + info.Message; got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCustomLanguageErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string // Error after the path of the file
+	}{
+		{"no colon", "name: NAME\npattern '^(?P<filename>\\S+) (?P<message>.*)$'", `:2: expected key: value`},
+		{"regex escape in double quotes", `pattern: "^(?P<filename>\S+):(?P<line>\d+) (?P<message>.*)$"`, `:1: invalid double-quoted value "^(?P<filename>\S+):(?P<line>\d+) (?P<message>.*)$", single-quote it to take backslashes literally`},
+		{"missing pattern", "name: NAME\nfilename: path", `: missing pattern`},
+		{"invalid pattern", "name: NAME\npattern: '(?P<filename>[a-'", ": invalid pattern: error parsing regexp: missing closing ]: `[a-`"},
+		{"unknown field", "name: NAME\npattern: '(?P<filename>\\S+) (?P<message>.*)'\nfile: filename", `: unknown field "file" in mapping, expected one of filename, line, column, type, code, message`},
+		{"missing mapped group", "name: NAME\npattern: '(?P<filename>\\S+) (?P<message>.*)'\nline: lineno", `: pattern has no capture group "lineno" for field line`},
+		{"missing message group", "name: NAME\npattern: '(?P<filename>\\S+) (?P<msg>.*)'", `: pattern has no capture group for required field message`},
+		{"built-in name", "name: go\npattern: '(?P<filename>\\S+) (?P<message>.*)'", `: language "go" is built in`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writePatternFile(t, tt.data)
+			_, err := loadCustomLanguage(path)
+			if err == nil || err.Error() != path+tt.want {
+				t.Errorf("got error %v, want %s", err, path+tt.want)
+			}
+		})
+	}
+}
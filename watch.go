@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long a watched file must stay unchanged before it is parsed
+// again, so a build writing its log in many small chunks causes a single run.
+const watchSettle = 200 * time.Millisecond
+
+// watchFile parses path, then parses it again each time it changes, until the
+// process is interrupted. Each run clears the screen first when stdout is a terminal.
+//
+// Every run is a child process started with the same arguments minus -watch, so
+// nothing carries over between runs: not the multi-line context, the totals, nor the
+// output buffered by json-array, grouped or sarif. The exit code of a run is ignored.
+//
+// The directory is watched rather than the file itself, because editors and build
+// tools often replace a file (remove and recreate, or rename over it) instead of
+// writing to it. Truncating the file counts as a change like any other write.
+func watchFile(path string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := withoutWatchFlag(os.Args[1:])
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	target := filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(target)); err != nil {
+		return err
+	}
+
+	fi, err := os.Stdout.Stat()
+	clearScreen := err == nil && fi.Mode()&os.ModeCharDevice != 0
+
+	run := func() {
+		if clearScreen {
+			fmt.Print("\033[H\033[2J")
+		}
+		if _, err := os.Stat(target); err != nil {
+			fmt.Fprintf(os.Stderr, "Waiting for %s to be created\n", path)
+			return
+		}
+		cmd := exec.Command(self, args...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = nil, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			if _, ok := err.(*exec.ExitError); !ok {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Watching %s for changes (Ctrl+C to stop)\n", path)
+	}
+
+	run()
+	settle := time.NewTimer(0)
+	<-settle.C
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == target && event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) {
+				settle.Reset(watchSettle) // Run once the writes have stopped
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return err
+		case <-settle.C:
+			run()
+		}
+	}
+}
+
+// withoutWatchFlag returns the command line arguments without -watch, in any of
+// the forms the flag package accepts (-watch, --watch, -watch=true).
+func withoutWatchFlag(args []string) []string {
+	var out []string
+	for i, arg := range args {
+		if arg == "--" {
+			return append(out, args[i:]...) // Everything after "--" is an argument
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if arg != name && (name == "watch" || strings.HasPrefix(name, "watch=")) {
+			continue
+		}
+		out = append(out, arg)
+	}
+	return out
+}